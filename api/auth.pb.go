@@ -24,17 +24,23 @@ const (
 	CreateAccountResponse_NO_ERROR               CreateAccountResponse_Error = 0
 	CreateAccountResponse_USER_NAME_ALREADY_USED CreateAccountResponse_Error = 1
 	CreateAccountResponse_NOT_ENCRYPTED          CreateAccountResponse_Error = 2
+	CreateAccountResponse_INVALID_USER_NAME      CreateAccountResponse_Error = 3
+	CreateAccountResponse_INVALID_PASSWORD       CreateAccountResponse_Error = 4
 )
 
 var CreateAccountResponse_Error_name = map[int32]string{
 	0: "NO_ERROR",
 	1: "USER_NAME_ALREADY_USED",
 	2: "NOT_ENCRYPTED",
+	3: "INVALID_USER_NAME",
+	4: "INVALID_PASSWORD",
 }
 var CreateAccountResponse_Error_value = map[string]int32{
 	"NO_ERROR":               0,
 	"USER_NAME_ALREADY_USED": 1,
 	"NOT_ENCRYPTED":          2,
+	"INVALID_USER_NAME":      3,
+	"INVALID_PASSWORD":       4,
 }
 
 func (x CreateAccountResponse_Error) String() string {
@@ -44,6 +50,35 @@ func (CreateAccountResponse_Error) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_auth_c5b33b636c78fb40, []int{3, 0}
 }
 
+type RegisterKeyResponse_Error int32
+
+const (
+	RegisterKeyResponse_NO_ERROR               RegisterKeyResponse_Error = 0
+	RegisterKeyResponse_KEY_ALREADY_REGISTERED RegisterKeyResponse_Error = 1
+	RegisterKeyResponse_BAD_KEY                RegisterKeyResponse_Error = 2
+	RegisterKeyResponse_BAD_SIGNATURE          RegisterKeyResponse_Error = 3
+)
+
+var RegisterKeyResponse_Error_name = map[int32]string{
+	0: "NO_ERROR",
+	1: "KEY_ALREADY_REGISTERED",
+	2: "BAD_KEY",
+	3: "BAD_SIGNATURE",
+}
+var RegisterKeyResponse_Error_value = map[string]int32{
+	"NO_ERROR":               0,
+	"KEY_ALREADY_REGISTERED": 1,
+	"BAD_KEY":                2,
+	"BAD_SIGNATURE":          3,
+}
+
+func (x RegisterKeyResponse_Error) String() string {
+	return proto.EnumName(RegisterKeyResponse_Error_name, int32(x))
+}
+func (RegisterKeyResponse_Error) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{17, 0}
+}
+
 type AuthRequest struct {
 	// Types that are valid to be assigned to R:
 	//	*AuthRequest_Auth
@@ -55,6 +90,7 @@ type AuthRequest struct {
 	//	*AuthRequest_CreateAccount
 	//	*AuthRequest_ListKeys
 	//	*AuthRequest_ListSessions
+	//	*AuthRequest_RegisterKey
 	R                    isAuthRequest_R `protobuf_oneof:"r"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
@@ -92,31 +128,43 @@ type isAuthRequest_R interface {
 type AuthRequest_Auth struct {
 	Auth *Auth1Request `protobuf:"bytes,1,opt,name=auth,proto3,oneof"`
 }
+
 type AuthRequest_AuthGuest struct {
 	AuthGuest *Auth1GuestRequest `protobuf:"bytes,2,opt,name=auth_guest,json=authGuest,proto3,oneof"`
 }
+
 type AuthRequest_Auth2 struct {
 	Auth2 *Auth2Request `protobuf:"bytes,3,opt,name=auth2,proto3,oneof"`
 }
+
 type AuthRequest_RefreshToken struct {
 	RefreshToken *RefreshTokenRequest `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3,oneof"`
 }
+
 type AuthRequest_RevokeSessions struct {
 	RevokeSessions *RevokeSessionsRequest `protobuf:"bytes,5,opt,name=revoke_sessions,json=revokeSessions,proto3,oneof"`
 }
+
 type AuthRequest_RevokeKeys struct {
 	RevokeKeys *RevokeKeysRequest `protobuf:"bytes,6,opt,name=revoke_keys,json=revokeKeys,proto3,oneof"`
 }
+
 type AuthRequest_CreateAccount struct {
 	CreateAccount *CreateAccountRequest `protobuf:"bytes,7,opt,name=create_account,json=createAccount,proto3,oneof"`
 }
+
 type AuthRequest_ListKeys struct {
 	ListKeys *ListKeys `protobuf:"bytes,8,opt,name=list_keys,json=listKeys,proto3,oneof"`
 }
+
 type AuthRequest_ListSessions struct {
 	ListSessions *ListSessions `protobuf:"bytes,9,opt,name=list_sessions,json=listSessions,proto3,oneof"`
 }
 
+type AuthRequest_RegisterKey struct {
+	RegisterKey *RegisterKeyRequest `protobuf:"bytes,10,opt,name=register_key,json=registerKey,proto3,oneof"`
+}
+
 func (*AuthRequest_Auth) isAuthRequest_R()           {}
 func (*AuthRequest_AuthGuest) isAuthRequest_R()      {}
 func (*AuthRequest_Auth2) isAuthRequest_R()          {}
@@ -126,6 +174,7 @@ func (*AuthRequest_RevokeKeys) isAuthRequest_R()     {}
 func (*AuthRequest_CreateAccount) isAuthRequest_R()  {}
 func (*AuthRequest_ListKeys) isAuthRequest_R()       {}
 func (*AuthRequest_ListSessions) isAuthRequest_R()   {}
+func (*AuthRequest_RegisterKey) isAuthRequest_R()    {}
 
 func (m *AuthRequest) GetR() isAuthRequest_R {
 	if m != nil {
@@ -197,6 +246,13 @@ func (m *AuthRequest) GetListSessions() *ListSessions {
 	return nil
 }
 
+func (m *AuthRequest) GetRegisterKey() *RegisterKeyRequest {
+	if x, ok := m.GetR().(*AuthRequest_RegisterKey); ok {
+		return x.RegisterKey
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AuthRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AuthRequest_OneofMarshaler, _AuthRequest_OneofUnmarshaler, _AuthRequest_OneofSizer, []interface{}{
@@ -209,6 +265,7 @@ func (*AuthRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) e
 		(*AuthRequest_CreateAccount)(nil),
 		(*AuthRequest_ListKeys)(nil),
 		(*AuthRequest_ListSessions)(nil),
+		(*AuthRequest_RegisterKey)(nil),
 	}
 }
 
@@ -261,6 +318,11 @@ func _AuthRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ListSessions); err != nil {
 			return err
 		}
+	case *AuthRequest_RegisterKey:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RegisterKey); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AuthRequest.R has unexpected type %T", x)
@@ -343,6 +405,14 @@ func _AuthRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Bu
 		err := b.DecodeMessage(msg)
 		m.R = &AuthRequest_ListSessions{msg}
 		return true, err
+	case 10: // r.register_key
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RegisterKeyRequest)
+		err := b.DecodeMessage(msg)
+		m.R = &AuthRequest_RegisterKey{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -397,6 +467,11 @@ func _AuthRequest_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AuthRequest_RegisterKey:
+		s := proto.Size(x.RegisterKey)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -415,6 +490,7 @@ type AuthResponse struct {
 	//	*AuthResponse_CreateAccount
 	//	*AuthResponse_ListKeys
 	//	*AuthResponse_ListSessions
+	//	*AuthResponse_RegisterKey
 	R                    isAuthResponse_R `protobuf_oneof:"r"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
@@ -452,31 +528,43 @@ type isAuthResponse_R interface {
 type AuthResponse_Auth struct {
 	Auth *Auth1Response `protobuf:"bytes,1,opt,name=auth,proto3,oneof"`
 }
+
 type AuthResponse_AuthGuest struct {
 	AuthGuest *Auth1GuestResponse `protobuf:"bytes,2,opt,name=auth_guest,json=authGuest,proto3,oneof"`
 }
+
 type AuthResponse_Auth2 struct {
 	Auth2 *Auth2Response `protobuf:"bytes,3,opt,name=auth2,proto3,oneof"`
 }
+
 type AuthResponse_RefreshToken struct {
 	RefreshToken *RefreshTokenResponse `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3,oneof"`
 }
+
 type AuthResponse_RevokeSessions struct {
 	RevokeSessions *RevokeSessionsResponse `protobuf:"bytes,5,opt,name=revoke_sessions,json=revokeSessions,proto3,oneof"`
 }
+
 type AuthResponse_RevokeKeys struct {
 	RevokeKeys *RevokeKeysResponse `protobuf:"bytes,6,opt,name=revoke_keys,json=revokeKeys,proto3,oneof"`
 }
+
 type AuthResponse_CreateAccount struct {
 	CreateAccount *CreateAccountResponse `protobuf:"bytes,7,opt,name=create_account,json=createAccount,proto3,oneof"`
 }
+
 type AuthResponse_ListKeys struct {
 	ListKeys *KeyList `protobuf:"bytes,8,opt,name=list_keys,json=listKeys,proto3,oneof"`
 }
+
 type AuthResponse_ListSessions struct {
 	ListSessions *SessionList `protobuf:"bytes,9,opt,name=list_sessions,json=listSessions,proto3,oneof"`
 }
 
+type AuthResponse_RegisterKey struct {
+	RegisterKey *RegisterKeyResponse `protobuf:"bytes,10,opt,name=register_key,json=registerKey,proto3,oneof"`
+}
+
 func (*AuthResponse_Auth) isAuthResponse_R()           {}
 func (*AuthResponse_AuthGuest) isAuthResponse_R()      {}
 func (*AuthResponse_Auth2) isAuthResponse_R()          {}
@@ -486,6 +574,7 @@ func (*AuthResponse_RevokeKeys) isAuthResponse_R()     {}
 func (*AuthResponse_CreateAccount) isAuthResponse_R()  {}
 func (*AuthResponse_ListKeys) isAuthResponse_R()       {}
 func (*AuthResponse_ListSessions) isAuthResponse_R()   {}
+func (*AuthResponse_RegisterKey) isAuthResponse_R()    {}
 
 func (m *AuthResponse) GetR() isAuthResponse_R {
 	if m != nil {
@@ -557,6 +646,13 @@ func (m *AuthResponse) GetListSessions() *SessionList {
 	return nil
 }
 
+func (m *AuthResponse) GetRegisterKey() *RegisterKeyResponse {
+	if x, ok := m.GetR().(*AuthResponse_RegisterKey); ok {
+		return x.RegisterKey
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AuthResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AuthResponse_OneofMarshaler, _AuthResponse_OneofUnmarshaler, _AuthResponse_OneofSizer, []interface{}{
@@ -569,6 +665,7 @@ func (*AuthResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer)
 		(*AuthResponse_CreateAccount)(nil),
 		(*AuthResponse_ListKeys)(nil),
 		(*AuthResponse_ListSessions)(nil),
+		(*AuthResponse_RegisterKey)(nil),
 	}
 }
 
@@ -621,6 +718,11 @@ func _AuthResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ListSessions); err != nil {
 			return err
 		}
+	case *AuthResponse_RegisterKey:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RegisterKey); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AuthResponse.R has unexpected type %T", x)
@@ -703,6 +805,14 @@ func _AuthResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.B
 		err := b.DecodeMessage(msg)
 		m.R = &AuthResponse_ListSessions{msg}
 		return true, err
+	case 10: // r.register_key
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RegisterKeyResponse)
+		err := b.DecodeMessage(msg)
+		m.R = &AuthResponse_RegisterKey{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -757,6 +867,11 @@ func _AuthResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AuthResponse_RegisterKey:
+		s := proto.Size(x.RegisterKey)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -892,6 +1007,13 @@ func (m *Auth1Request) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Auth1Request proto.InternalMessageInfo
 
+func (m *Auth1Request) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
 type isAuth1Request_A interface {
 	isAuth1Request_A()
 }
@@ -899,6 +1021,7 @@ type isAuth1Request_A interface {
 type Auth1Request_Password_ struct {
 	Password *Auth1Request_Password `protobuf:"bytes,2,opt,name=password,proto3,oneof"`
 }
+
 type Auth1Request_PubKey_ struct {
 	PubKey *Auth1Request_PubKey `protobuf:"bytes,3,opt,name=pub_key,json=pubKey,proto3,oneof"`
 }
@@ -913,13 +1036,6 @@ func (m *Auth1Request) GetA() isAuth1Request_A {
 	return nil
 }
 
-func (m *Auth1Request) GetUserId() string {
-	if m != nil {
-		return m.UserId
-	}
-	return ""
-}
-
 func (m *Auth1Request) GetPassword() *Auth1Request_Password {
 	if x, ok := m.GetA().(*Auth1Request_Password_); ok {
 		return x.Password
@@ -1054,7 +1170,11 @@ func (m *Auth1Request_Password) GetClientNonce() []byte {
 }
 
 type Auth1Request_PubKey struct {
-	EccPub               []byte   `protobuf:"bytes,1,opt,name=ecc_pub,json=eccPub,proto3" json:"ecc_pub,omitempty"`
+	// a device's Ed25519 key registered with RegisterKeyRequest
+	EccPub      []byte `protobuf:"bytes,1,opt,name=ecc_pub,json=eccPub,proto3" json:"ecc_pub,omitempty"`
+	ClientNonce []byte `protobuf:"bytes,2,opt,name=client_nonce,json=clientNonce,proto3" json:"client_nonce,omitempty"`
+	// an X25519 key the device made for just this login
+	DhPub                []byte   `protobuf:"bytes,3,opt,name=dh_pub,json=dhPub,proto3" json:"dh_pub,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1091,6 +1211,20 @@ func (m *Auth1Request_PubKey) GetEccPub() []byte {
 	return nil
 }
 
+func (m *Auth1Request_PubKey) GetClientNonce() []byte {
+	if m != nil {
+		return m.ClientNonce
+	}
+	return nil
+}
+
+func (m *Auth1Request_PubKey) GetDhPub() []byte {
+	if m != nil {
+		return m.DhPub
+	}
+	return nil
+}
+
 type Auth1Response struct {
 	// new session token used to identify this session
 	Token []byte `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -1129,6 +1263,13 @@ func (m *Auth1Response) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Auth1Response proto.InternalMessageInfo
 
+func (m *Auth1Response) GetToken() []byte {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
 type isAuth1Response_A interface {
 	isAuth1Response_A()
 }
@@ -1136,6 +1277,7 @@ type isAuth1Response_A interface {
 type Auth1Response_Password_ struct {
 	Password *Auth1Response_Password `protobuf:"bytes,2,opt,name=password,proto3,oneof"`
 }
+
 type Auth1Response_PubKey_ struct {
 	PubKey *Auth1Response_PubKey `protobuf:"bytes,3,opt,name=pub_key,json=pubKey,proto3,oneof"`
 }
@@ -1150,13 +1292,6 @@ func (m *Auth1Response) GetA() isAuth1Response_A {
 	return nil
 }
 
-func (m *Auth1Response) GetToken() []byte {
-	if m != nil {
-		return m.Token
-	}
-	return nil
-}
-
 func (m *Auth1Response) GetPassword() *Auth1Response_Password {
 	if x, ok := m.GetA().(*Auth1Response_Password_); ok {
 		return x.Password
@@ -1292,7 +1427,9 @@ func (m *Auth1Response_Password) GetSalt() []byte {
 }
 
 type Auth1Response_PubKey struct {
+	// the server's X25519 key for this session only
 	EccPub               []byte   `protobuf:"bytes,1,opt,name=ecc_pub,json=eccPub,proto3" json:"ecc_pub,omitempty"`
+	ServerNonce          []byte   `protobuf:"bytes,2,opt,name=server_nonce,json=serverNonce,proto3" json:"server_nonce,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1329,6 +1466,13 @@ func (m *Auth1Response_PubKey) GetEccPub() []byte {
 	return nil
 }
 
+func (m *Auth1Response_PubKey) GetServerNonce() []byte {
+	if m != nil {
+		return m.ServerNonce
+	}
+	return nil
+}
+
 type Auth1GuestRequest struct {
 	GuestPub             []byte   `protobuf:"bytes,1,opt,name=guest_pub,json=guestPub,proto3" json:"guest_pub,omitempty"`
 	GuestNonce           []byte   `protobuf:"bytes,2,opt,name=guest_nonce,json=guestNonce,proto3" json:"guest_nonce,omitempty"`
@@ -1431,7 +1575,11 @@ func (m *Auth1GuestResponse) GetSessionToken() []byte {
 
 // second set of messages to ensure credentials are good
 type Auth2Request struct {
-	EnableEncryption     bool     `protobuf:"varint,1,opt,name=enable_encryption,json=enableEncryption,proto3" json:"enable_encryption,omitempty"`
+	EnableEncryption bool `protobuf:"varint,1,opt,name=enable_encryption,json=enableEncryption,proto3" json:"enable_encryption,omitempty"`
+	// random bytes for deriving the encryption keys, if enabling encryption
+	ClientInput []byte `protobuf:"bytes,2,opt,name=client_input,json=clientInput,proto3" json:"client_input,omitempty"`
+	// for a device key login, the device key's signature of the handshake
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1468,9 +1616,25 @@ func (m *Auth2Request) GetEnableEncryption() bool {
 	return false
 }
 
+func (m *Auth2Request) GetClientInput() []byte {
+	if m != nil {
+		return m.ClientInput
+	}
+	return nil
+}
+
+func (m *Auth2Request) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type Auth2Response struct {
-	EncryptionEnabled    bool     `protobuf:"varint,1,opt,name=encryption_enabled,json=encryptionEnabled,proto3" json:"encryption_enabled,omitempty"`
-	TokenExpiry          uint64   `protobuf:"varint,2,opt,name=token_expiry,json=tokenExpiry,proto3" json:"token_expiry,omitempty"`
+	EncryptionEnabled bool   `protobuf:"varint,1,opt,name=encryption_enabled,json=encryptionEnabled,proto3" json:"encryption_enabled,omitempty"`
+	TokenExpiry       uint64 `protobuf:"varint,2,opt,name=token_expiry,json=tokenExpiry,proto3" json:"token_expiry,omitempty"`
+	// the server's half of the random bytes for the encryption keys
+	ServerInput          []byte   `protobuf:"bytes,3,opt,name=server_input,json=serverInput,proto3" json:"server_input,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1514,6 +1678,13 @@ func (m *Auth2Response) GetTokenExpiry() uint64 {
 	return 0
 }
 
+func (m *Auth2Response) GetServerInput() []byte {
+	if m != nil {
+		return m.ServerInput
+	}
+	return nil
+}
+
 // generate a new token with new secret credentials
 type RefreshTokenRequest struct {
 	ClientInput          []byte   `protobuf:"bytes,1,opt,name=client_input,json=clientInput,proto3" json:"client_input,omitempty"`
@@ -1775,6 +1946,109 @@ func (m *RevokeKeysResponse) GetSuccess() []bool {
 	return nil
 }
 
+// adds a device's Ed25519 key that can be used to log in instead of the
+// password. signature is the key's signature of RegisterKeyMessage for the
+// session it's registered in, to show the device holds the private key
+type RegisterKeyRequest struct {
+	EccPub               []byte   `protobuf:"bytes,1,opt,name=ecc_pub,json=eccPub,proto3" json:"ecc_pub,omitempty"`
+	DeviceType           []byte   `protobuf:"bytes,2,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterKeyRequest) Reset()         { *m = RegisterKeyRequest{} }
+func (m *RegisterKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterKeyRequest) ProtoMessage()    {}
+func (*RegisterKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{16}
+}
+func (m *RegisterKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterKeyRequest.Unmarshal(m, b)
+}
+func (m *RegisterKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *RegisterKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterKeyRequest.Merge(dst, src)
+}
+func (m *RegisterKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterKeyRequest.Size(m)
+}
+func (m *RegisterKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterKeyRequest proto.InternalMessageInfo
+
+func (m *RegisterKeyRequest) GetEccPub() []byte {
+	if m != nil {
+		return m.EccPub
+	}
+	return nil
+}
+
+func (m *RegisterKeyRequest) GetDeviceType() []byte {
+	if m != nil {
+		return m.DeviceType
+	}
+	return nil
+}
+
+func (m *RegisterKeyRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type RegisterKeyResponse struct {
+	Success              bool                      `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error                RegisterKeyResponse_Error `protobuf:"varint,2,opt,name=error,proto3,enum=api.RegisterKeyResponse_Error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *RegisterKeyResponse) Reset()         { *m = RegisterKeyResponse{} }
+func (m *RegisterKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterKeyResponse) ProtoMessage()    {}
+func (*RegisterKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{17}
+}
+func (m *RegisterKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterKeyResponse.Unmarshal(m, b)
+}
+func (m *RegisterKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *RegisterKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterKeyResponse.Merge(dst, src)
+}
+func (m *RegisterKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterKeyResponse.Size(m)
+}
+func (m *RegisterKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterKeyResponse proto.InternalMessageInfo
+
+func (m *RegisterKeyResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *RegisterKeyResponse) GetError() RegisterKeyResponse_Error {
+	if m != nil {
+		return m.Error
+	}
+	return RegisterKeyResponse_NO_ERROR
+}
+
 type ListKeys struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1785,7 +2059,7 @@ func (m *ListKeys) Reset()         { *m = ListKeys{} }
 func (m *ListKeys) String() string { return proto.CompactTextString(m) }
 func (*ListKeys) ProtoMessage()    {}
 func (*ListKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_c5b33b636c78fb40, []int{16}
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{18}
 }
 func (m *ListKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListKeys.Unmarshal(m, b)
@@ -1817,7 +2091,7 @@ func (m *KeyList) Reset()         { *m = KeyList{} }
 func (m *KeyList) String() string { return proto.CompactTextString(m) }
 func (*KeyList) ProtoMessage()    {}
 func (*KeyList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_c5b33b636c78fb40, []int{17}
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{19}
 }
 func (m *KeyList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyList.Unmarshal(m, b)
@@ -1861,7 +2135,7 @@ func (m *ListSessions) Reset()         { *m = ListSessions{} }
 func (m *ListSessions) String() string { return proto.CompactTextString(m) }
 func (*ListSessions) ProtoMessage()    {}
 func (*ListSessions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_c5b33b636c78fb40, []int{18}
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{20}
 }
 func (m *ListSessions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSessions.Unmarshal(m, b)
@@ -1894,7 +2168,7 @@ func (m *SessionList) Reset()         { *m = SessionList{} }
 func (m *SessionList) String() string { return proto.CompactTextString(m) }
 func (*SessionList) ProtoMessage()    {}
 func (*SessionList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_c5b33b636c78fb40, []int{19}
+	return fileDescriptor_auth_c5b33b636c78fb40, []int{21}
 }
 func (m *SessionList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SessionList.Unmarshal(m, b)
@@ -1956,80 +2230,95 @@ func init() {
 	proto.RegisterType((*RevokeSessionsResponse)(nil), "api.RevokeSessionsResponse")
 	proto.RegisterType((*RevokeKeysRequest)(nil), "api.RevokeKeysRequest")
 	proto.RegisterType((*RevokeKeysResponse)(nil), "api.RevokeKeysResponse")
+	proto.RegisterType((*RegisterKeyRequest)(nil), "api.RegisterKeyRequest")
+	proto.RegisterType((*RegisterKeyResponse)(nil), "api.RegisterKeyResponse")
 	proto.RegisterType((*ListKeys)(nil), "api.ListKeys")
 	proto.RegisterType((*KeyList)(nil), "api.KeyList")
 	proto.RegisterType((*ListSessions)(nil), "api.ListSessions")
 	proto.RegisterType((*SessionList)(nil), "api.SessionList")
 	proto.RegisterEnum("api.CreateAccountResponse_Error", CreateAccountResponse_Error_name, CreateAccountResponse_Error_value)
+	proto.RegisterEnum("api.RegisterKeyResponse_Error", RegisterKeyResponse_Error_name, RegisterKeyResponse_Error_value)
 }
 
 func init() { proto.RegisterFile("auth.proto", fileDescriptor_auth_c5b33b636c78fb40) }
 
 var fileDescriptor_auth_c5b33b636c78fb40 = []byte{
-	// 1028 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x5f, 0x73, 0xdb, 0x44,
-	0x10, 0x8f, 0xff, 0xdb, 0x6b, 0x39, 0xd8, 0x47, 0x9a, 0x28, 0xce, 0x00, 0xae, 0x78, 0x68, 0xa0,
-	0xd4, 0x33, 0x4d, 0x18, 0x9a, 0xc2, 0x0c, 0xe0, 0x24, 0x82, 0x74, 0x52, 0x9c, 0x70, 0x49, 0x1f,
-	0xfa, 0xa4, 0x91, 0xe5, 0x83, 0x88, 0x78, 0x24, 0xa1, 0x3f, 0x01, 0x0f, 0x4f, 0x7c, 0x81, 0x3e,
-	0xf1, 0x3d, 0xf8, 0x8a, 0xcc, 0xed, 0xc9, 0xd2, 0xc9, 0xba, 0xa4, 0xbc, 0xe9, 0x76, 0x7f, 0xbf,
-	0xbd, 0xdb, 0xbd, 0xdf, 0xed, 0x0a, 0xc0, 0x4e, 0xe2, 0x9b, 0x71, 0x10, 0xfa, 0xb1, 0x4f, 0x6a,
-	0x76, 0xe0, 0x1a, 0xef, 0xea, 0xd0, 0x9d, 0x24, 0xf1, 0x0d, 0x65, 0xbf, 0x27, 0x2c, 0x8a, 0xc9,
-	0x13, 0xa8, 0x73, 0x88, 0x5e, 0x19, 0x55, 0xf6, 0xbb, 0x07, 0x83, 0xb1, 0x1d, 0xb8, 0x63, 0xee,
-	0x7f, 0x9e, 0x02, 0xce, 0x36, 0x28, 0x02, 0xc8, 0x0b, 0x11, 0xcb, 0xfa, 0x95, 0x5b, 0xf5, 0x2a,
-	0xc2, 0xb7, 0x73, 0xf8, 0x8f, 0xdc, 0x9c, 0x73, 0x3a, 0x1c, 0x8b, 0x36, 0xf2, 0x19, 0x34, 0xf8,
-	0xe2, 0x40, 0xaf, 0xad, 0x6d, 0x71, 0x90, 0xc3, 0x05, 0x82, 0x7c, 0x07, 0xbd, 0x90, 0xfd, 0x12,
-	0xb2, 0xe8, 0xc6, 0x8a, 0xfd, 0x5b, 0xe6, 0xe9, 0x75, 0xa4, 0xe8, 0x48, 0xa1, 0xc2, 0x73, 0xcd,
-	0x1d, 0x39, 0x53, 0x0b, 0x25, 0x33, 0x31, 0xe1, 0x83, 0x90, 0xdd, 0xf9, 0xb7, 0xcc, 0x8a, 0x58,
-	0x14, 0xb9, 0xbe, 0x17, 0xe9, 0x0d, 0x0c, 0x31, 0x4c, 0x43, 0x70, 0xdf, 0x55, 0xea, 0xca, 0x83,
-	0x6c, 0x86, 0x05, 0x07, 0x79, 0x09, 0xdd, 0x34, 0xcc, 0x2d, 0x5b, 0x46, 0x7a, 0x53, 0x4a, 0x56,
-	0x84, 0x38, 0x67, 0x4b, 0x89, 0x0e, 0x61, 0x66, 0x24, 0xc7, 0xb0, 0xe9, 0x84, 0xcc, 0x8e, 0x99,
-	0x65, 0x3b, 0x8e, 0x9f, 0x78, 0xb1, 0xde, 0x42, 0xf6, 0x2e, 0xb2, 0x4f, 0xd0, 0x35, 0x11, 0x9e,
-	0x3c, 0x40, 0xcf, 0x91, 0xed, 0xe4, 0x0b, 0xe8, 0x2c, 0xdc, 0x28, 0x16, 0x9b, 0xb7, 0x91, 0xde,
-	0x43, 0xfa, 0x6b, 0x37, 0x8a, 0xf9, 0x2e, 0x67, 0x1b, 0xb4, 0xbd, 0x48, 0xbf, 0xc9, 0x11, 0xf4,
-	0x10, 0x9d, 0x65, 0xdc, 0x91, 0xea, 0xcc, 0x19, 0xab, 0xb4, 0x78, 0xb5, 0x16, 0xd2, 0xfa, 0xb8,
-	0x06, 0x95, 0xd0, 0xf8, 0xa7, 0x0e, 0x9a, 0x10, 0x44, 0x14, 0xf8, 0x5e, 0xc4, 0xc8, 0x7e, 0x41,
-	0x11, 0x44, 0x56, 0x84, 0x40, 0x64, 0x92, 0x38, 0x52, 0x48, 0x62, 0xa7, 0x24, 0x89, 0x8c, 0x24,
-	0x69, 0xe2, 0xf3, 0xa2, 0x26, 0x88, 0xac, 0x89, 0x0c, 0x9f, 0x8a, 0xe2, 0x7b, 0xb5, 0x28, 0x76,
-	0x15, 0xa2, 0xc8, 0xa8, 0x45, 0x55, 0xfc, 0x70, 0x9f, 0x2a, 0xf6, 0x94, 0xaa, 0xc8, 0xa2, 0xac,
-	0xcb, 0xe2, 0x6b, 0x95, 0x2c, 0x76, 0x4a, 0xb2, 0xc8, 0xf8, 0xb2, 0x2e, 0x4e, 0xee, 0xd1, 0xc5,
-	0x50, 0xa5, 0x8b, 0x2c, 0xc2, 0x9a, 0x30, 0x9e, 0x96, 0x85, 0xa1, 0x21, 0xff, 0x9c, 0x2d, 0xf9,
-	0x4d, 0x17, 0x74, 0xf1, 0x42, 0xad, 0x8b, 0x3e, 0x12, 0xd2, 0x9c, 0x52, 0x92, 0x42, 0x16, 0xe7,
-	0xb0, 0xa5, 0x12, 0x2b, 0xd9, 0x81, 0x56, 0x12, 0xb1, 0xd0, 0x72, 0xe7, 0x28, 0x90, 0x0e, 0x6d,
-	0xf2, 0xe5, 0xab, 0x39, 0x19, 0x42, 0x3b, 0xb0, 0xa3, 0xe8, 0x0f, 0x3f, 0x9c, 0xa3, 0x14, 0x3a,
-	0x34, 0x5b, 0x1b, 0xff, 0x56, 0xe0, 0x91, 0x32, 0x45, 0xa2, 0x43, 0x2b, 0x4a, 0x1c, 0x87, 0x45,
-	0x11, 0x86, 0x6b, 0xd3, 0xd5, 0x92, 0x7c, 0x05, 0x0d, 0x16, 0x86, 0x7e, 0x88, 0xc1, 0x36, 0x0f,
-	0x46, 0xf7, 0xd7, 0x69, 0x6c, 0x72, 0x1c, 0x15, 0x70, 0xe3, 0x14, 0x1a, 0xb8, 0x26, 0x1a, 0xb4,
-	0xa7, 0x17, 0x96, 0x49, 0xe9, 0x05, 0xed, 0x6f, 0x90, 0x21, 0x6c, 0xbf, 0xb9, 0x32, 0xa9, 0x35,
-	0x9d, 0xfc, 0x64, 0x5a, 0x93, 0xd7, 0xd4, 0x9c, 0x9c, 0xbe, 0xb5, 0xde, 0x5c, 0x99, 0xa7, 0xfd,
-	0x0a, 0x19, 0x40, 0x6f, 0x7a, 0x71, 0x6d, 0x99, 0xd3, 0x13, 0xfa, 0xf6, 0xf2, 0xda, 0x3c, 0xed,
-	0x57, 0x8d, 0x77, 0x55, 0xf1, 0x2a, 0x9e, 0xbf, 0x37, 0xef, 0xa3, 0xb5, 0xbc, 0x57, 0x57, 0x2a,
-	0xb3, 0xc7, 0x97, 0x29, 0x82, 0x5f, 0xd0, 0x0a, 0x4d, 0x0e, 0xa1, 0x15, 0x24, 0x33, 0x7e, 0x99,
-	0xe9, 0x33, 0xd0, 0x15, 0xc4, 0x64, 0x76, 0xce, 0x96, 0x67, 0x1b, 0xb4, 0x19, 0xe0, 0x17, 0xf9,
-	0x04, 0xba, 0x73, 0x76, 0xe7, 0x3a, 0xcc, 0x8a, 0x97, 0x01, 0xc3, 0xb7, 0xa0, 0x51, 0x10, 0xa6,
-	0xeb, 0x65, 0xc0, 0x86, 0xcf, 0xa0, 0xbd, 0xda, 0x8d, 0x3c, 0x06, 0xcd, 0x59, 0xb8, 0xcc, 0x8b,
-	0x2d, 0xcf, 0xf7, 0x1c, 0x86, 0x27, 0xd7, 0x68, 0x57, 0xd8, 0xa6, 0xdc, 0x34, 0x7c, 0x0c, 0x4d,
-	0xb1, 0x07, 0xcf, 0x90, 0x39, 0x8e, 0x15, 0x24, 0xb3, 0x14, 0xd7, 0x64, 0x8e, 0x73, 0x99, 0xcc,
-	0xb8, 0x1e, 0x6c, 0xe3, 0xef, 0x2a, 0xf4, 0x0a, 0x5d, 0x80, 0x6c, 0x41, 0x43, 0xbc, 0x47, 0x81,
-	0x16, 0x0b, 0xf2, 0xb2, 0x54, 0x8e, 0xbd, 0x72, 0x07, 0x51, 0xd7, 0xe3, 0xcb, 0xf5, 0x7a, 0xec,
-	0xaa, 0x98, 0x6b, 0x05, 0x19, 0x4e, 0x8a, 0xf9, 0x46, 0x2c, 0xbc, 0x63, 0x61, 0x31, 0x5f, 0x61,
-	0xc3, 0x7c, 0x09, 0x81, 0x7a, 0x64, 0x2f, 0x44, 0xb7, 0xd2, 0x28, 0x7e, 0xff, 0xef, 0x1a, 0xfc,
-	0x0c, 0x83, 0xd2, 0xac, 0x23, 0x7b, 0xd0, 0xc1, 0xfe, 0x27, 0x91, 0xda, 0x68, 0xb8, 0x4c, 0x66,
-	0xfc, 0xb6, 0x84, 0x53, 0x9c, 0x47, 0x6c, 0x0a, 0x68, 0xc2, 0xe3, 0x18, 0x7f, 0x01, 0x29, 0xf7,
-	0x4a, 0xf2, 0x11, 0x40, 0x9a, 0x47, 0x1e, 0xb4, 0x23, 0x2c, 0x3c, 0xea, 0x7a, 0x9a, 0xd5, 0x72,
-	0x9a, 0x9f, 0x42, 0x2f, 0x7d, 0xf7, 0x69, 0xd3, 0xac, 0x21, 0x46, 0x4b, 0x8d, 0xd8, 0x17, 0x8d,
-	0x6f, 0x84, 0xc6, 0x57, 0x73, 0x98, 0x3c, 0x85, 0x01, 0xf3, 0xec, 0xd9, 0x82, 0x59, 0xcc, 0x73,
-	0xc2, 0x65, 0x10, 0xbb, 0xbe, 0x97, 0x3e, 0xcb, 0xbe, 0x70, 0x98, 0x99, 0xdd, 0xb0, 0x85, 0x1e,
-	0xb2, 0x86, 0x4d, 0x9e, 0x01, 0xc9, 0x69, 0x96, 0xc0, 0xcf, 0x53, 0xfa, 0x20, 0xf7, 0x98, 0xc2,
-	0xc1, 0x93, 0xc0, 0x93, 0x59, 0xec, 0xcf, 0xc0, 0x0d, 0x97, 0x98, 0x44, 0x9d, 0x76, 0xd1, 0x66,
-	0xa2, 0xc9, 0x38, 0x82, 0x0f, 0x15, 0x43, 0x5f, 0x52, 0xb5, 0xeb, 0x05, 0x49, 0x5c, 0x54, 0xf5,
-	0x2b, 0x6e, 0x32, 0x42, 0xd8, 0x52, 0x4d, 0x86, 0x7b, 0x34, 0x9b, 0xd7, 0x53, 0x04, 0x2c, 0xd4,
-	0x13, 0x03, 0x96, 0x4e, 0x5b, 0x2b, 0x9f, 0xf6, 0x10, 0x1e, 0x29, 0xff, 0x2f, 0x78, 0x67, 0xcc,
-	0x7a, 0x70, 0x65, 0x54, 0xe3, 0x02, 0x59, 0xad, 0x8d, 0x29, 0x6c, 0xab, 0xc7, 0xcf, 0x43, 0x2c,
-	0xb9, 0x6b, 0x56, 0x47, 0x35, 0xa9, 0x6b, 0x1a, 0x4f, 0x60, 0x50, 0xfa, 0x43, 0xe1, 0x9a, 0xc7,
-	0x89, 0x21, 0xc2, 0xe0, 0xb7, 0x71, 0x0c, 0xa4, 0x3c, 0xb3, 0x54, 0xc8, 0x07, 0x36, 0x03, 0x68,
-	0xaf, 0xfe, 0x48, 0x8c, 0x6f, 0xa1, 0x95, 0x0e, 0x21, 0x65, 0x90, 0xb5, 0xb6, 0x55, 0x45, 0x97,
-	0xd4, 0xb6, 0x8c, 0x4d, 0xd0, 0xe4, 0x7f, 0x15, 0xe3, 0x37, 0xe8, 0x4a, 0x33, 0xea, 0xc1, 0x6a,
-	0xbc, 0x2f, 0x36, 0xf9, 0x18, 0x00, 0xaf, 0xcd, 0x46, 0x41, 0xd7, 0x46, 0xb5, 0xfd, 0x3a, 0x95,
-	0x2c, 0xb3, 0x26, 0xfe, 0x1f, 0x1f, 0xfe, 0x17, 0x00, 0x00, 0xff, 0xff, 0xaf, 0x45, 0x86, 0x27,
-	0x2d, 0x0b, 0x00, 0x00,
+	// 1223 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x57, 0x5b, 0x73, 0xdb, 0x44,
+	0x14, 0x8e, 0x2d, 0x5f, 0x8f, 0xe5, 0x60, 0x6f, 0x73, 0x71, 0x1c, 0x68, 0x83, 0x78, 0x68, 0xa0,
+	0x34, 0x33, 0x24, 0x1d, 0x9a, 0x32, 0xdc, 0x9c, 0x58, 0x24, 0x1e, 0x07, 0x27, 0x5d, 0x3b, 0x30,
+	0x19, 0x1e, 0x34, 0xb6, 0xbc, 0x24, 0x22, 0x1e, 0x59, 0x5d, 0x49, 0x01, 0x0f, 0x3c, 0xf2, 0x53,
+	0xf8, 0x11, 0xfc, 0x04, 0x1e, 0x78, 0xe3, 0x95, 0x1f, 0xd3, 0xd9, 0x5d, 0x59, 0x5a, 0x59, 0x72,
+	0xd2, 0x37, 0xed, 0xb9, 0x7c, 0xe7, 0xe2, 0x6f, 0xcf, 0x59, 0x03, 0x0c, 0x7d, 0xef, 0x66, 0xcf,
+	0xa1, 0x53, 0x6f, 0x8a, 0x94, 0xa1, 0x63, 0x69, 0xff, 0xe6, 0xa0, 0xd2, 0xf2, 0xbd, 0x1b, 0x4c,
+	0xde, 0xf8, 0xc4, 0xf5, 0xd0, 0x53, 0xc8, 0x31, 0x93, 0x46, 0x66, 0x27, 0xb3, 0x5b, 0xd9, 0xaf,
+	0xef, 0x0d, 0x1d, 0x6b, 0x8f, 0xe9, 0x3f, 0x0b, 0x0c, 0x4e, 0x57, 0x30, 0x37, 0x40, 0x2f, 0x05,
+	0x96, 0x71, 0xcd, 0xa4, 0x8d, 0x2c, 0x37, 0xdf, 0x88, 0xcc, 0x4f, 0x98, 0x38, 0xf2, 0x29, 0x33,
+	0x5b, 0x2e, 0x43, 0x1f, 0x43, 0x9e, 0x1d, 0xf6, 0x1b, 0xca, 0x42, 0x88, 0xfd, 0xc8, 0x5c, 0x58,
+	0xa0, 0x6f, 0xa0, 0x4a, 0xc9, 0xcf, 0x94, 0xb8, 0x37, 0x86, 0x37, 0xbd, 0x25, 0x76, 0x23, 0xc7,
+	0x5d, 0x1a, 0xdc, 0x05, 0x0b, 0xcd, 0x80, 0x29, 0x22, 0x4f, 0x95, 0x4a, 0x62, 0xa4, 0xc3, 0x7b,
+	0x94, 0xdc, 0x4d, 0x6f, 0x89, 0xe1, 0x12, 0xd7, 0xb5, 0xa6, 0xb6, 0xdb, 0xc8, 0x73, 0x88, 0x66,
+	0x00, 0xc1, 0x74, 0xfd, 0x40, 0x15, 0x81, 0xac, 0xd2, 0x98, 0x02, 0xbd, 0x82, 0x4a, 0x00, 0x73,
+	0x4b, 0x66, 0x6e, 0xa3, 0x20, 0x15, 0x2b, 0x20, 0xba, 0x64, 0x26, 0xb9, 0x03, 0x0d, 0x85, 0xe8,
+	0x08, 0x56, 0x4d, 0x4a, 0x86, 0x1e, 0x31, 0x86, 0xa6, 0x39, 0xf5, 0x6d, 0xaf, 0x51, 0xe4, 0xde,
+	0x5b, 0xdc, 0xfb, 0x98, 0xab, 0x5a, 0x42, 0x13, 0x01, 0x54, 0x4d, 0x59, 0x8e, 0x3e, 0x85, 0xf2,
+	0xc4, 0x72, 0x3d, 0x11, 0xbc, 0xc4, 0xdd, 0xab, 0xdc, 0xfd, 0xcc, 0x72, 0x3d, 0x16, 0xe5, 0x74,
+	0x05, 0x97, 0x26, 0xc1, 0x37, 0x3a, 0x84, 0x2a, 0xb7, 0x0e, 0x2b, 0x2e, 0x4b, 0x7d, 0x66, 0x1e,
+	0xf3, 0xb2, 0x58, 0xb7, 0x26, 0xd2, 0x19, 0x7d, 0x09, 0x2a, 0x25, 0xd7, 0x96, 0xeb, 0x11, 0xca,
+	0x62, 0x35, 0x80, 0x3b, 0x6e, 0x06, 0x75, 0x0a, 0x45, 0x97, 0xcc, 0xa2, 0x3c, 0x2b, 0x34, 0x92,
+	0x1e, 0x29, 0x90, 0xa1, 0xda, 0x7f, 0x39, 0x50, 0x05, 0x9d, 0x5c, 0x67, 0x6a, 0xbb, 0x04, 0xed,
+	0xc6, 0xf8, 0x84, 0x64, 0x3e, 0x09, 0x8b, 0x90, 0x50, 0x87, 0x29, 0x84, 0xda, 0x4c, 0x10, 0x2a,
+	0x74, 0x92, 0x18, 0xf5, 0x49, 0x9c, 0x51, 0x48, 0x66, 0x54, 0x68, 0x1f, 0x50, 0xea, 0xdb, 0x74,
+	0x4a, 0x6d, 0xa5, 0x50, 0x2a, 0x74, 0x8d, 0x73, 0xea, 0xbb, 0x65, 0x9c, 0xda, 0x4e, 0xe5, 0x54,
+	0x88, 0xb2, 0x48, 0xaa, 0x2f, 0xd2, 0x48, 0xb5, 0x99, 0x20, 0x55, 0xe8, 0x2f, 0xb3, 0xea, 0x78,
+	0x09, 0xab, 0x9a, 0x69, 0xac, 0x0a, 0x11, 0x16, 0x68, 0xf5, 0x2c, 0x49, 0x2b, 0x95, 0xfb, 0x77,
+	0xc9, 0x8c, 0xf1, 0x24, 0xc6, 0xaa, 0x97, 0xe9, 0xac, 0xaa, 0x71, 0x87, 0xa0, 0xa6, 0xc0, 0x29,
+	0x4e, 0xaa, 0xaf, 0x52, 0x49, 0xd5, 0x48, 0x92, 0x2a, 0x4c, 0x33, 0xc9, 0xaa, 0x2e, 0xac, 0xa5,
+	0xdd, 0x14, 0xb4, 0x09, 0x45, 0xdf, 0x25, 0xd4, 0xb0, 0xc6, 0x9c, 0x5f, 0x65, 0x5c, 0x60, 0xc7,
+	0xce, 0x18, 0x35, 0xa1, 0xe4, 0x0c, 0x5d, 0xf7, 0xd7, 0x29, 0x1d, 0x73, 0x26, 0x95, 0x71, 0x78,
+	0xd6, 0xfe, 0xcf, 0xc0, 0x7a, 0x6a, 0x87, 0x50, 0x03, 0x8a, 0xae, 0x6f, 0x9a, 0xc4, 0x75, 0x39,
+	0x5c, 0x09, 0xcf, 0x8f, 0xe8, 0x73, 0xc8, 0x13, 0x4a, 0xa7, 0x94, 0x83, 0xad, 0xee, 0xef, 0x2c,
+	0x6f, 0xf3, 0x9e, 0xce, 0xec, 0xb0, 0x30, 0xd7, 0xde, 0x40, 0x9e, 0x9f, 0x91, 0x0a, 0xa5, 0xde,
+	0xb9, 0xa1, 0x63, 0x7c, 0x8e, 0x6b, 0x2b, 0xa8, 0x09, 0x1b, 0x97, 0x7d, 0x1d, 0x1b, 0xbd, 0xd6,
+	0xf7, 0xba, 0xd1, 0x3a, 0xc3, 0x7a, 0xab, 0x7d, 0x65, 0x5c, 0xf6, 0xf5, 0x76, 0x2d, 0x83, 0xea,
+	0x50, 0xed, 0x9d, 0x0f, 0x0c, 0xbd, 0x77, 0x8c, 0xaf, 0x2e, 0x06, 0x7a, 0xbb, 0x96, 0x45, 0xeb,
+	0x50, 0xef, 0xf4, 0x7e, 0x68, 0x9d, 0x75, 0xda, 0x46, 0xe8, 0x56, 0x53, 0xd0, 0x1a, 0xd4, 0xe6,
+	0xe2, 0x8b, 0x56, 0xbf, 0xff, 0xe3, 0x39, 0x6e, 0xd7, 0x72, 0xda, 0x3f, 0x59, 0x50, 0xe5, 0x81,
+	0xbd, 0xbc, 0x49, 0x87, 0x0b, 0x4d, 0x9a, 0xd3, 0x47, 0xf6, 0xde, 0xbb, 0x08, 0x2c, 0x18, 0x19,
+	0xe6, 0xd6, 0xe8, 0x00, 0x8a, 0x8e, 0x3f, 0xe2, 0x3f, 0xa7, 0x22, 0xfd, 0x9c, 0x71, 0x47, 0x7f,
+	0xd4, 0x25, 0xb3, 0xd3, 0x15, 0x5c, 0x70, 0xf8, 0x17, 0x7a, 0x02, 0x95, 0x31, 0xb9, 0xb3, 0x4c,
+	0x62, 0x78, 0x33, 0x87, 0xf0, 0x7b, 0xa7, 0x62, 0x10, 0xa2, 0xc1, 0xcc, 0x21, 0xcd, 0xe7, 0x50,
+	0x9a, 0x47, 0x43, 0x1f, 0x82, 0x6a, 0x4e, 0x2c, 0x62, 0x7b, 0x86, 0x3d, 0xb5, 0x4d, 0xc2, 0x33,
+	0x57, 0x71, 0x45, 0xc8, 0x7a, 0x4c, 0xd4, 0xfc, 0x09, 0x0a, 0x22, 0x06, 0xab, 0x90, 0x98, 0xa6,
+	0xe1, 0xf8, 0xa3, 0xc0, 0xae, 0x40, 0x4c, 0xf3, 0xc2, 0x1f, 0x25, 0x50, 0xb2, 0x09, 0x14, 0xb4,
+	0x0e, 0x85, 0xf1, 0x0d, 0x77, 0x55, 0xb8, 0x32, 0x3f, 0xbe, 0xb9, 0xf0, 0x47, 0x8c, 0x76, 0x43,
+	0xed, 0xaf, 0x2c, 0x54, 0x63, 0xb3, 0x0a, 0xad, 0x41, 0x5e, 0x4c, 0x0d, 0x11, 0x47, 0x1c, 0xd0,
+	0xab, 0x44, 0x23, 0xb7, 0x93, 0x73, 0x2e, 0xbd, 0x93, 0x2f, 0x16, 0x3b, 0xb9, 0x95, 0xe6, 0xb9,
+	0xd0, 0xca, 0x66, 0x2b, 0xde, 0x29, 0x97, 0xd0, 0x3b, 0x42, 0xe3, 0x9d, 0x12, 0x32, 0x51, 0x23,
+	0x82, 0x9c, 0x3b, 0x9c, 0x78, 0x41, 0xf9, 0xfc, 0xbb, 0xd9, 0x7e, 0xa7, 0xee, 0xc5, 0x90, 0xb3,
+	0x09, 0x64, 0xd1, 0xa6, 0xd7, 0x50, 0x4f, 0xac, 0x7c, 0xb4, 0x0d, 0x65, 0x3e, 0xc8, 0x25, 0xdc,
+	0x12, 0x17, 0x30, 0xe4, 0x27, 0x50, 0x11, 0x4a, 0x19, 0x18, 0xb8, 0x88, 0xe3, 0x6a, 0xbf, 0x03,
+	0x4a, 0x0e, 0x7d, 0xf4, 0x01, 0x40, 0x90, 0x50, 0x04, 0x5a, 0x16, 0x92, 0x77, 0xcb, 0x17, 0x7d,
+	0x04, 0xd5, 0x60, 0x80, 0x05, 0xd3, 0x5f, 0xfc, 0xe8, 0x6a, 0x20, 0xe4, 0x03, 0x5e, 0xfb, 0x03,
+	0x54, 0xf9, 0x39, 0x82, 0x9e, 0x41, 0x9d, 0xd8, 0xc3, 0xd1, 0x84, 0x18, 0xc4, 0x36, 0xe9, 0xcc,
+	0xf1, 0xac, 0xa9, 0x1d, 0x0c, 0x88, 0x9a, 0x50, 0xe8, 0xa1, 0x5c, 0xa2, 0x9c, 0x65, 0x3b, 0xbe,
+	0x17, 0xa7, 0x5c, 0x87, 0x89, 0xd0, 0xfb, 0x50, 0x76, 0xad, 0x6b, 0x7b, 0xe8, 0xf9, 0x94, 0x04,
+	0x09, 0x44, 0x02, 0xed, 0xcf, 0x0c, 0x54, 0x63, 0xbb, 0x0b, 0x3d, 0x07, 0x14, 0x05, 0x36, 0x44,
+	0xc4, 0x71, 0x90, 0x40, 0x3d, 0xd2, 0xe8, 0x42, 0xc1, 0x32, 0xe0, 0xb5, 0x19, 0xe4, 0x37, 0xc7,
+	0xa2, 0x33, 0x9e, 0x41, 0x0e, 0x57, 0xb8, 0x4c, 0xe7, 0x22, 0xa9, 0x53, 0x22, 0x49, 0x45, 0xee,
+	0x14, 0x4f, 0x52, 0x3b, 0x84, 0x47, 0x29, 0x0f, 0xac, 0x44, 0x79, 0x99, 0x44, 0x79, 0x1a, 0x85,
+	0xb5, 0xb4, 0x3d, 0xba, 0xe4, 0xee, 0x2c, 0xa6, 0x92, 0x4d, 0xa4, 0x92, 0x28, 0x48, 0x49, 0x14,
+	0xa4, 0x1d, 0xc0, 0x7a, 0xea, 0x5b, 0x8e, 0x2d, 0x82, 0x70, 0x63, 0x65, 0x76, 0x14, 0xc6, 0xc2,
+	0xf9, 0x59, 0xeb, 0xc1, 0x46, 0xfa, 0xb2, 0xbe, 0xcf, 0x4b, 0x5e, 0x12, 0xd9, 0x1d, 0x45, 0x5a,
+	0x12, 0xda, 0x53, 0xa8, 0x27, 0x5e, 0x83, 0xec, 0xee, 0xf1, 0xfd, 0x2a, 0x60, 0xf8, 0xb7, 0x76,
+	0x04, 0x28, 0xb9, 0xe1, 0xd3, 0x2c, 0xef, 0x09, 0x36, 0x61, 0x18, 0x8b, 0x4f, 0xb2, 0xe5, 0x77,
+	0x79, 0x61, 0xf8, 0x66, 0x17, 0x87, 0xef, 0x03, 0xa4, 0xfc, 0x3b, 0x03, 0x8f, 0x62, 0xe1, 0x1e,
+	0xdc, 0x98, 0x2f, 0xe2, 0x1b, 0xf3, 0xf1, 0xb2, 0x7d, 0x1f, 0xdf, 0x97, 0xaf, 0x97, 0xee, 0xcb,
+	0xae, 0x7e, 0x15, 0x6e, 0x4a, 0xac, 0x9f, 0x74, 0xfa, 0x03, 0x1d, 0xf3, 0x7d, 0x59, 0x81, 0xe2,
+	0x51, 0xab, 0x6d, 0x74, 0xf5, 0xab, 0x5a, 0x96, 0x2d, 0x4f, 0x76, 0xe8, 0x77, 0x4e, 0x7a, 0xad,
+	0xc1, 0x25, 0xd6, 0x6b, 0x8a, 0x06, 0x50, 0x9a, 0x3f, 0x93, 0xb5, 0xaf, 0xa1, 0x18, 0xbc, 0x6d,
+	0x52, 0xbb, 0x9d, 0x68, 0x92, 0x12, 0x6f, 0x92, 0xb6, 0x0a, 0xaa, 0xfc, 0x80, 0xd6, 0x7e, 0x81,
+	0x8a, 0xf4, 0xf4, 0xb9, 0x97, 0x36, 0x0f, 0x61, 0xa3, 0xc7, 0x00, 0x9c, 0xdf, 0x43, 0x3e, 0x5e,
+	0x94, 0x1d, 0x65, 0x37, 0x87, 0x25, 0xc9, 0xa8, 0xc0, 0xff, 0xb4, 0x1d, 0xbc, 0x1d, 0x00, 0x8e,
+	0x58, 0x1c, 0x91, 0xc2, 0x0d, 0x00, 0x00,
 }
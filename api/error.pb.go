@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: error.proto
+
+package api
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type InvalidRequest_Code int32
+
+const (
+	InvalidRequest_UNKNOWN_ERROR     InvalidRequest_Code = 0
+	InvalidRequest_AUTH_ERROR        InvalidRequest_Code = 1
+	InvalidRequest_WAS_RESPONSE      InvalidRequest_Code = 2
+	InvalidRequest_MALFORMED_REQUEST InvalidRequest_Code = 3
+	InvalidRequest_INCOMPATIBLE      InvalidRequest_Code = 4
+	InvalidRequest_TOKEN_EXPIRED     InvalidRequest_Code = 5
+	InvalidRequest_REPLAYED          InvalidRequest_Code = 6
+	InvalidRequest_NOT_ALLOWED       InvalidRequest_Code = 7
+	InvalidRequest_BAD_HISTORY       InvalidRequest_Code = 8
+)
+
+var InvalidRequest_Code_name = map[int32]string{
+	0: "UNKNOWN_ERROR",
+	1: "AUTH_ERROR",
+	2: "WAS_RESPONSE",
+	3: "MALFORMED_REQUEST",
+	4: "INCOMPATIBLE",
+	5: "TOKEN_EXPIRED",
+	6: "REPLAYED",
+	7: "NOT_ALLOWED",
+	8: "BAD_HISTORY",
+}
+var InvalidRequest_Code_value = map[string]int32{
+	"UNKNOWN_ERROR":     0,
+	"AUTH_ERROR":        1,
+	"WAS_RESPONSE":      2,
+	"MALFORMED_REQUEST": 3,
+	"INCOMPATIBLE":      4,
+	"TOKEN_EXPIRED":     5,
+	"REPLAYED":          6,
+	"NOT_ALLOWED":       7,
+	"BAD_HISTORY":       8,
+}
+
+func (x InvalidRequest_Code) String() string {
+	return proto.EnumName(InvalidRequest_Code_name, int32(x))
+}
+func (InvalidRequest_Code) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_error_fa7386566e798864, []int{0, 0}
+}
+
+// why a request, or an action in a game request, was turned away
+type InvalidRequest struct {
+	Code                 InvalidRequest_Code `protobuf:"varint,1,opt,name=code,proto3,enum=api.InvalidRequest_Code" json:"code,omitempty"`
+	Reason               string              `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *InvalidRequest) Reset()         { *m = InvalidRequest{} }
+func (m *InvalidRequest) String() string { return proto.CompactTextString(m) }
+func (*InvalidRequest) ProtoMessage()    {}
+func (*InvalidRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_error_fa7386566e798864, []int{0}
+}
+func (m *InvalidRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvalidRequest.Unmarshal(m, b)
+}
+func (m *InvalidRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvalidRequest.Marshal(b, m, deterministic)
+}
+func (dst *InvalidRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidRequest.Merge(dst, src)
+}
+func (m *InvalidRequest) XXX_Size() int {
+	return xxx_messageInfo_InvalidRequest.Size(m)
+}
+func (m *InvalidRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidRequest proto.InternalMessageInfo
+
+func (m *InvalidRequest) GetCode() InvalidRequest_Code {
+	if m != nil {
+		return m.Code
+	}
+	return InvalidRequest_UNKNOWN_ERROR
+}
+
+func (m *InvalidRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*InvalidRequest)(nil), "api.InvalidRequest")
+	proto.RegisterEnum("api.InvalidRequest_Code", InvalidRequest_Code_name, InvalidRequest_Code_value)
+}
+
+func init() { proto.RegisterFile("error.proto", fileDescriptor_error_fa7386566e798864) }
+
+var fileDescriptor_error_fa7386566e798864 = []byte{
+	// 257 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0xd0, 0xc1, 0x4e, 0xc2, 0x40,
+	0x10, 0xc6, 0x71, 0x0b, 0x88, 0x38, 0x20, 0x2e, 0x9b, 0x68, 0x7a, 0x24, 0x9c, 0x38, 0x98, 0x1e,
+	0xf4, 0x09, 0x16, 0x3a, 0x86, 0x86, 0x76, 0xb7, 0x4e, 0xb7, 0xa9, 0x9c, 0x36, 0xd5, 0xee, 0xa1,
+	0x89, 0x61, 0x6b, 0x41, 0xcf, 0xbe, 0x8d, 0xaf, 0x69, 0x4a, 0xb8, 0x78, 0xfc, 0x4f, 0x7e, 0x99,
+	0xc3, 0x07, 0x63, 0xdb, 0xb6, 0xae, 0x0d, 0x9a, 0xd6, 0x1d, 0x1d, 0xef, 0x97, 0x4d, 0xbd, 0xf8,
+	0xe9, 0xc1, 0x34, 0xda, 0x7f, 0x97, 0x1f, 0x75, 0x45, 0xf6, 0xf3, 0xcb, 0x1e, 0x8e, 0xfc, 0x01,
+	0x06, 0xef, 0xae, 0xb2, 0xbe, 0x37, 0xf7, 0x96, 0xd3, 0x47, 0x3f, 0x28, 0x9b, 0x3a, 0xf8, 0x4f,
+	0x82, 0xb5, 0xab, 0x2c, 0x9d, 0x14, 0xbf, 0x87, 0x61, 0x6b, 0xcb, 0x83, 0xdb, 0xfb, 0xbd, 0xb9,
+	0xb7, 0xbc, 0xa6, 0x73, 0x2d, 0x7e, 0x3d, 0x18, 0x74, 0x8c, 0xcf, 0xe0, 0x26, 0x97, 0x5b, 0xa9,
+	0x0a, 0x69, 0x90, 0x48, 0x11, 0xbb, 0xe0, 0x53, 0x00, 0x91, 0xeb, 0xcd, 0xb9, 0x3d, 0xce, 0x60,
+	0x52, 0x88, 0xcc, 0x10, 0x66, 0xa9, 0x92, 0x19, 0xb2, 0x1e, 0xbf, 0x83, 0x59, 0x22, 0xe2, 0x67,
+	0x45, 0x09, 0x86, 0x86, 0xf0, 0x25, 0xc7, 0x4c, 0xb3, 0x7e, 0x07, 0x23, 0xb9, 0x56, 0x49, 0x2a,
+	0x74, 0xb4, 0x8a, 0x91, 0x0d, 0xba, 0xef, 0x5a, 0x6d, 0x51, 0x1a, 0x7c, 0x4d, 0x23, 0xc2, 0x90,
+	0x5d, 0xf2, 0x09, 0x8c, 0x08, 0xd3, 0x58, 0xec, 0x30, 0x64, 0x43, 0x7e, 0x0b, 0x63, 0xa9, 0xb4,
+	0x11, 0x71, 0xac, 0x0a, 0x0c, 0xd9, 0x55, 0x77, 0x58, 0x89, 0xd0, 0x6c, 0xa2, 0x4c, 0x2b, 0xda,
+	0xb1, 0xd1, 0xdb, 0xf0, 0x34, 0xc7, 0xd3, 0xdf, 0x00, 0x19, 0x4e, 0x14, 0xb2, 0x1d, 0x01, 0x00,
+	0x00,
+}
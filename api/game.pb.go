@@ -82,17 +82,18 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 type GameState int32
 
 const (
-	GameState_WhiteMove      GameState = 0
-	GameState_BlackMove      GameState = 1
-	GameState_WhiteCheckmate GameState = 2
-	GameState_BlackCheckmate GameState = 3
-	GameState_WhiteStalemate GameState = 4
-	GameState_BlackStalemate GameState = 5
-	GameState_WhiteResigned  GameState = 6
-	GameState_BlackResigned  GameState = 7
-	GameState_DrawAgreed     GameState = 8
-	GameState_Draw50Moves    GameState = 9
-	GameState_Draw3Fold      GameState = 10
+	GameState_WhiteMove                GameState = 0
+	GameState_BlackMove                GameState = 1
+	GameState_WhiteCheckmate           GameState = 2
+	GameState_BlackCheckmate           GameState = 3
+	GameState_WhiteStalemate           GameState = 4
+	GameState_BlackStalemate           GameState = 5
+	GameState_WhiteResigned            GameState = 6
+	GameState_BlackResigned            GameState = 7
+	GameState_DrawAgreed               GameState = 8
+	GameState_Draw50Moves              GameState = 9
+	GameState_Draw3Fold                GameState = 10
+	GameState_DrawInsufficientMaterial GameState = 11
 )
 
 var GameState_name = map[int32]string{
@@ -107,19 +108,21 @@ var GameState_name = map[int32]string{
 	8:  "DrawAgreed",
 	9:  "Draw50Moves",
 	10: "Draw3Fold",
+	11: "DrawInsufficientMaterial",
 }
 var GameState_value = map[string]int32{
-	"WhiteMove":      0,
-	"BlackMove":      1,
-	"WhiteCheckmate": 2,
-	"BlackCheckmate": 3,
-	"WhiteStalemate": 4,
-	"BlackStalemate": 5,
-	"WhiteResigned":  6,
-	"BlackResigned":  7,
-	"DrawAgreed":     8,
-	"Draw50Moves":    9,
-	"Draw3Fold":      10,
+	"WhiteMove":                0,
+	"BlackMove":                1,
+	"WhiteCheckmate":           2,
+	"BlackCheckmate":           3,
+	"WhiteStalemate":           4,
+	"BlackStalemate":           5,
+	"WhiteResigned":            6,
+	"BlackResigned":            7,
+	"DrawAgreed":               8,
+	"Draw50Moves":              9,
+	"Draw3Fold":                10,
+	"DrawInsufficientMaterial": 11,
 }
 
 func (x GameState) String() string {
@@ -155,6 +158,99 @@ func (Move_Castle) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_game_d035ff30c88d20b9, []int{2, 0}
 }
 
+type MoveResult_Error int32
+
+const (
+	MoveResult_NO_ERROR        MoveResult_Error = 0
+	MoveResult_GAME_ENDED      MoveResult_Error = 1
+	MoveResult_NOT_YOUR_TURN   MoveResult_Error = 2
+	MoveResult_WRONG_SIDE      MoveResult_Error = 3
+	MoveResult_OUT_OF_BOUNDS   MoveResult_Error = 4
+	MoveResult_PIECE_NOT_FOUND MoveResult_Error = 5
+	MoveResult_INVALID_MOVE    MoveResult_Error = 6
+	MoveResult_IN_CHECK        MoveResult_Error = 7
+	MoveResult_CANT_CASTLE     MoveResult_Error = 8
+	MoveResult_MALFORMED_MOVE  MoveResult_Error = 9
+)
+
+var MoveResult_Error_name = map[int32]string{
+	0: "NO_ERROR",
+	1: "GAME_ENDED",
+	2: "NOT_YOUR_TURN",
+	3: "WRONG_SIDE",
+	4: "OUT_OF_BOUNDS",
+	5: "PIECE_NOT_FOUND",
+	6: "INVALID_MOVE",
+	7: "IN_CHECK",
+	8: "CANT_CASTLE",
+	9: "MALFORMED_MOVE",
+}
+var MoveResult_Error_value = map[string]int32{
+	"NO_ERROR":        0,
+	"GAME_ENDED":      1,
+	"NOT_YOUR_TURN":   2,
+	"WRONG_SIDE":      3,
+	"OUT_OF_BOUNDS":   4,
+	"PIECE_NOT_FOUND": 5,
+	"INVALID_MOVE":    6,
+	"IN_CHECK":        7,
+	"CANT_CASTLE":     8,
+	"MALFORMED_MOVE":  9,
+}
+
+func (x MoveResult_Error) String() string {
+	return proto.EnumName(MoveResult_Error_name, int32(x))
+}
+func (MoveResult_Error) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_game_d035ff30c88d20b9, []int{31, 0}
+}
+
+type ResignResult_Error int32
+
+const (
+	ResignResult_NO_ERROR   ResignResult_Error = 0
+	ResignResult_GAME_ENDED ResignResult_Error = 1
+)
+
+var ResignResult_Error_name = map[int32]string{
+	0: "NO_ERROR",
+	1: "GAME_ENDED",
+}
+var ResignResult_Error_value = map[string]int32{
+	"NO_ERROR":   0,
+	"GAME_ENDED": 1,
+}
+
+func (x ResignResult_Error) String() string {
+	return proto.EnumName(ResignResult_Error_name, int32(x))
+}
+func (ResignResult_Error) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_game_d035ff30c88d20b9, []int{32, 0}
+}
+
+type DrawResult_Error int32
+
+const (
+	DrawResult_NO_ERROR   DrawResult_Error = 0
+	DrawResult_GAME_ENDED DrawResult_Error = 1
+)
+
+var DrawResult_Error_name = map[int32]string{
+	0: "NO_ERROR",
+	1: "GAME_ENDED",
+}
+var DrawResult_Error_value = map[string]int32{
+	"NO_ERROR":   0,
+	"GAME_ENDED": 1,
+}
+
+func (x DrawResult_Error) String() string {
+	return proto.EnumName(DrawResult_Error_name, int32(x))
+}
+func (DrawResult_Error) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_game_d035ff30c88d20b9, []int{33, 0}
+}
+
 type Position struct {
 	X                    int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
 	Y                    int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
@@ -935,10 +1031,13 @@ type PlayerResult struct {
 	//	*PlayerResult_Profile
 	//	*PlayerResult_ModifySuccess
 	//	*PlayerResult_ListedPlayerId
-	Results              isPlayerResult_Results `protobuf_oneof:"results"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Results isPlayerResult_Results `protobuf_oneof:"results"`
+	// set if the action failed, in place of a result; the actions after it in
+	// the request aren't done
+	Error                *InvalidRequest `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *PlayerResult) Reset()         { *m = PlayerResult{} }
@@ -1013,6 +1112,13 @@ func (m *PlayerResult) GetActionId() []byte {
 	return nil
 }
 
+func (m *PlayerResult) GetError() *InvalidRequest {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 func (m *PlayerResult) GetGames() *GameSummaries {
 	if x, ok := m.GetResults().(*PlayerResult_Games); ok {
 		return x.Games
@@ -1574,10 +1680,13 @@ type GameResult struct {
 	//	*GameResult_DrawResult
 	//	*GameResult_Spectate
 	//	*GameResult_Unspectate
-	Actions              isGameResult_Actions `protobuf_oneof:"actions"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Actions isGameResult_Actions `protobuf_oneof:"actions"`
+	// set if the action failed, in place of a result; the actions after it in
+	// the request aren't done
+	Error                *InvalidRequest `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *GameResult) Reset()         { *m = GameResult{} }
@@ -1656,6 +1765,13 @@ func (m *GameResult) GetActionId() []byte {
 	return nil
 }
 
+func (m *GameResult) GetError() *InvalidRequest {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 func (m *GameResult) GetSummary() *GameSummary {
 	if x, ok := m.GetActions().(*GameResult_Summary); ok {
 		return x.Summary
@@ -2542,18 +2658,20 @@ func (m *Draw) XXX_DiscardUnknown() {
 var xxx_messageInfo_Draw proto.InternalMessageInfo
 
 type GameSummary struct {
-	State                GameState `protobuf:"varint,1,opt,name=state,proto3,enum=api.GameState" json:"state,omitempty"`
-	White                [][]byte  `protobuf:"bytes,2,rep,name=white,proto3" json:"white,omitempty"`
-	Black                [][]byte  `protobuf:"bytes,3,rep,name=black,proto3" json:"black,omitempty"`
-	Spectating           [][]byte  `protobuf:"bytes,4,rep,name=spectating,proto3" json:"spectating,omitempty"`
-	WhiteCheck           bool      `protobuf:"varint,5,opt,name=white_check,json=whiteCheck,proto3" json:"white_check,omitempty"`
-	BlackCheck           bool      `protobuf:"varint,6,opt,name=black_check,json=blackCheck,proto3" json:"black_check,omitempty"`
-	WhiteDraw            bool      `protobuf:"varint,7,opt,name=white_draw,json=whiteDraw,proto3" json:"white_draw,omitempty"`
-	BlackDraw            bool      `protobuf:"varint,8,opt,name=black_draw,json=blackDraw,proto3" json:"black_draw,omitempty"`
-	MovesSinceCapture    int64     `protobuf:"varint,9,opt,name=moves_since_capture,json=movesSinceCapture,proto3" json:"moves_since_capture,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	State             GameState `protobuf:"varint,1,opt,name=state,proto3,enum=api.GameState" json:"state,omitempty"`
+	White             [][]byte  `protobuf:"bytes,2,rep,name=white,proto3" json:"white,omitempty"`
+	Black             [][]byte  `protobuf:"bytes,3,rep,name=black,proto3" json:"black,omitempty"`
+	Spectating        [][]byte  `protobuf:"bytes,4,rep,name=spectating,proto3" json:"spectating,omitempty"`
+	WhiteCheck        bool      `protobuf:"varint,5,opt,name=white_check,json=whiteCheck,proto3" json:"white_check,omitempty"`
+	BlackCheck        bool      `protobuf:"varint,6,opt,name=black_check,json=blackCheck,proto3" json:"black_check,omitempty"`
+	WhiteDraw         bool      `protobuf:"varint,7,opt,name=white_draw,json=whiteDraw,proto3" json:"white_draw,omitempty"`
+	BlackDraw         bool      `protobuf:"varint,8,opt,name=black_draw,json=blackDraw,proto3" json:"black_draw,omitempty"`
+	MovesSinceCapture int64     `protobuf:"varint,9,opt,name=moves_since_capture,json=movesSinceCapture,proto3" json:"moves_since_capture,omitempty"`
+	// the id to act on the game with
+	GameId               []byte   `protobuf:"bytes,10,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GameSummary) Reset()         { *m = GameSummary{} }
@@ -2643,6 +2761,13 @@ func (m *GameSummary) GetMovesSinceCapture() int64 {
 	return 0
 }
 
+func (m *GameSummary) GetGameId() []byte {
+	if m != nil {
+		return m.GameId
+	}
+	return nil
+}
+
 type Board struct {
 	Inplay               []*Piece     `protobuf:"bytes,1,rep,name=inplay,proto3" json:"inplay,omitempty"`
 	Captured             []*Piece     `protobuf:"bytes,2,rep,name=captured,proto3" json:"captured,omitempty"`
@@ -2706,11 +2831,12 @@ func (m *Board) GetGs() *GameSummary {
 }
 
 type MoveResult struct {
-	Success              bool         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Result               *GameSummary `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Success              bool             `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Result               *GameSummary     `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error                MoveResult_Error `protobuf:"varint,3,opt,name=error,proto3,enum=api.MoveResult_Error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *MoveResult) Reset()         { *m = MoveResult{} }
@@ -2751,12 +2877,20 @@ func (m *MoveResult) GetResult() *GameSummary {
 	return nil
 }
 
+func (m *MoveResult) GetError() MoveResult_Error {
+	if m != nil {
+		return m.Error
+	}
+	return MoveResult_NO_ERROR
+}
+
 type ResignResult struct {
-	Success              bool         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Result               *GameSummary `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Success              bool               `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Result               *GameSummary       `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error                ResignResult_Error `protobuf:"varint,3,opt,name=error,proto3,enum=api.ResignResult_Error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ResignResult) Reset()         { *m = ResignResult{} }
@@ -2797,12 +2931,20 @@ func (m *ResignResult) GetResult() *GameSummary {
 	return nil
 }
 
+func (m *ResignResult) GetError() ResignResult_Error {
+	if m != nil {
+		return m.Error
+	}
+	return ResignResult_NO_ERROR
+}
+
 type DrawResult struct {
-	Success              bool         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Result               *GameSummary `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Success              bool             `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Result               *GameSummary     `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error                DrawResult_Error `protobuf:"varint,3,opt,name=error,proto3,enum=api.DrawResult_Error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *DrawResult) Reset()         { *m = DrawResult{} }
@@ -2843,6 +2985,13 @@ func (m *DrawResult) GetResult() *GameSummary {
 	return nil
 }
 
+func (m *DrawResult) GetError() DrawResult_Error {
+	if m != nil {
+		return m.Error
+	}
+	return DrawResult_NO_ERROR
+}
+
 type Notify struct {
 	Heartbeat            int64    `protobuf:"varint,1,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
 	Timeout              int64    `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
@@ -3425,133 +3574,151 @@ func init() {
 	proto.RegisterEnum("api.Type", Type_name, Type_value)
 	proto.RegisterEnum("api.GameState", GameState_name, GameState_value)
 	proto.RegisterEnum("api.Move_Castle", Move_Castle_name, Move_Castle_value)
+	proto.RegisterEnum("api.MoveResult_Error", MoveResult_Error_name, MoveResult_Error_value)
+	proto.RegisterEnum("api.ResignResult_Error", ResignResult_Error_name, ResignResult_Error_value)
+	proto.RegisterEnum("api.DrawResult_Error", DrawResult_Error_name, DrawResult_Error_value)
 }
 
 func init() { proto.RegisterFile("game.proto", fileDescriptor_game_d035ff30c88d20b9) }
 
 var fileDescriptor_game_d035ff30c88d20b9 = []byte{
-	// 1958 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0xc6, 0x2e, 0x16, 0xc0, 0xa2, 0xf1, 0xe0, 0x72, 0x64, 0x59, 0x50, 0x45, 0x0f, 0xd6, 0x3a,
-	0x92, 0x29, 0xda, 0x45, 0xc5, 0x74, 0x58, 0x4e, 0x95, 0x2a, 0x55, 0x21, 0x25, 0x8a, 0x40, 0x28,
-	0x41, 0xcc, 0x82, 0xb2, 0x8e, 0xa8, 0x15, 0x76, 0x08, 0x6e, 0x19, 0xbb, 0x80, 0x77, 0x16, 0xa6,
-	0x71, 0xcc, 0x39, 0xe5, 0x6b, 0x2a, 0x17, 0xff, 0x83, 0x54, 0xe5, 0x07, 0xe4, 0x47, 0xe4, 0x0f,
-	0xe5, 0x90, 0xea, 0x9e, 0x99, 0x7d, 0x80, 0x14, 0xad, 0x83, 0x0f, 0xb9, 0xa1, 0x1f, 0xd3, 0xd3,
-	0xdd, 0x5f, 0x3f, 0x66, 0x01, 0x30, 0xf5, 0x23, 0xbe, 0xbb, 0x48, 0xe6, 0xe9, 0x9c, 0x55, 0xfd,
-	0x45, 0xe8, 0x3e, 0x06, 0xfb, 0x74, 0x2e, 0xc2, 0x34, 0x9c, 0xc7, 0xac, 0x0d, 0xc6, 0x8f, 0x3d,
-	0x63, 0xcb, 0xd8, 0xae, 0x79, 0xc6, 0x8f, 0x48, 0xad, 0x7a, 0xa6, 0xa4, 0x56, 0xee, 0x4f, 0x06,
-	0xd4, 0x4e, 0x43, 0x3e, 0xe1, 0xec, 0x3e, 0x58, 0xe9, 0x6a, 0xc1, 0x49, 0xb1, 0xbb, 0xd7, 0xdc,
-	0xf5, 0x17, 0xe1, 0xee, 0xd9, 0x6a, 0xc1, 0x3d, 0x62, 0xb3, 0x27, 0x60, 0x2f, 0x94, 0x41, 0x3a,
-	0xdd, 0xda, 0xeb, 0x90, 0x8a, 0xbe, 0xc5, 0xcb, 0xc4, 0x68, 0x49, 0x84, 0x01, 0xef, 0x55, 0x0b,
-	0x96, 0x46, 0x61, 0xc0, 0x3d, 0x62, 0xb3, 0xdf, 0x40, 0xf3, 0xc2, 0x17, 0xe3, 0x68, 0xfe, 0x03,
-	0x0f, 0x7a, 0xd6, 0x96, 0xb1, 0x6d, 0x7b, 0xf6, 0x85, 0x2f, 0x5e, 0x23, 0xed, 0xfe, 0xd5, 0x04,
-	0x0b, 0x7f, 0xfd, 0x92, 0x3b, 0x9f, 0x41, 0x4d, 0xa4, 0x7e, 0x92, 0x5e, 0xef, 0x8b, 0x94, 0xb1,
-	0x87, 0x50, 0xe5, 0x71, 0x40, 0x7e, 0x5c, 0x51, 0x41, 0x09, 0xbb, 0x07, 0xcd, 0x45, 0x32, 0x8f,
-	0xe6, 0x14, 0x95, 0x74, 0x25, 0x67, 0xb0, 0x6d, 0xa8, 0x4f, 0x7c, 0x91, 0xce, 0x78, 0xaf, 0x46,
-	0x4e, 0x38, 0x64, 0x01, 0xbd, 0xdb, 0x7d, 0x4e, 0x7c, 0x4f, 0xc9, 0x31, 0xa4, 0xc5, 0xcc, 0x5f,
-	0xf1, 0x64, 0x1c, 0x06, 0xbd, 0xfa, 0x96, 0xb1, 0xdd, 0xf6, 0x6c, 0xc9, 0x18, 0x04, 0xee, 0x53,
-	0xa8, 0x4b, 0x75, 0x66, 0x83, 0x35, 0x7c, 0x33, 0x3c, 0x72, 0x2a, 0xac, 0x0d, 0xf6, 0xc9, 0x60,
-	0x78, 0x3c, 0x1a, 0xbc, 0x38, 0x72, 0x0c, 0xd6, 0x81, 0xe6, 0x5f, 0xde, 0x1e, 0x1d, 0x0d, 0x89,
-	0x34, 0xdd, 0x13, 0x68, 0x1d, 0xfb, 0x11, 0xf7, 0xf8, 0xf7, 0x4b, 0x2e, 0x52, 0xf6, 0x00, 0xcc,
-	0x85, 0xe8, 0x19, 0x5b, 0xd5, 0xed, 0xd6, 0x5e, 0x57, 0x06, 0x41, 0xa6, 0x3d, 0xfe, 0xbd, 0x67,
-	0x2e, 0x04, 0xbb, 0x07, 0xe6, 0x54, 0xf4, 0x4c, 0x92, 0xb7, 0x49, 0xae, 0x4e, 0x7b, 0xe6, 0x54,
-	0xb8, 0x43, 0x68, 0x4b, 0x52, 0x2c, 0xe6, 0xb1, 0xe0, 0xec, 0x61, 0xc1, 0xda, 0x46, 0xc9, 0x9a,
-	0x58, 0x90, 0xb9, 0xfb, 0x05, 0x73, 0x9d, 0x82, 0x39, 0x14, 0x4f, 0x85, 0xfb, 0x16, 0x9a, 0xd9,
-	0xf5, 0xe5, 0xb8, 0x8d, 0x72, 0xdc, 0xec, 0x0b, 0x68, 0xf8, 0x13, 0x4c, 0xa4, 0xb6, 0xb6, 0x59,
-	0xb8, 0xee, 0x80, 0x24, 0x9e, 0xd6, 0x70, 0xbf, 0x05, 0xc8, 0xfd, 0xf8, 0x45, 0xbb, 0x09, 0x17,
-	0xcb, 0x59, 0x7a, 0x9d, 0x5d, 0x8f, 0x24, 0x9e, 0xd6, 0x70, 0x5f, 0x43, 0x43, 0x65, 0x83, 0xdd,
-	0x81, 0x06, 0x76, 0x49, 0x6e, 0xb2, 0x8e, 0xe4, 0x20, 0x60, 0x4f, 0xd6, 0x1d, 0xdd, 0xc8, 0xc2,
-	0x5e, 0x77, 0x73, 0x08, 0xb6, 0xce, 0xc6, 0x8d, 0xf6, 0xca, 0x0e, 0x6e, 0x14, 0xd3, 0x58, 0x72,
-	0xef, 0xe7, 0x2a, 0xb4, 0x8b, 0x09, 0xc1, 0xc8, 0xe5, 0x5d, 0x85, 0xc8, 0x25, 0x63, 0x10, 0xb0,
-	0x7d, 0x80, 0x59, 0x28, 0xd2, 0x31, 0xde, 0x23, 0x54, 0xe5, 0x7f, 0x42, 0xb6, 0x5f, 0x85, 0x22,
-	0x45, 0x0b, 0x3f, 0x70, 0xbc, 0x45, 0xf4, 0x2b, 0x5e, 0x13, 0x35, 0x89, 0x60, 0xfb, 0x40, 0xc4,
-	0xf8, 0x22, 0x14, 0xa9, 0x6a, 0x86, 0x4f, 0xb3, 0x53, 0x2f, 0xc3, 0x38, 0x14, 0x17, 0x3c, 0xd0,
-	0xe7, 0x6c, 0x54, 0xed, 0x87, 0x22, 0x65, 0x4f, 0x01, 0xa8, 0x8d, 0xe8, 0x3a, 0x6a, 0x01, 0x5d,
-	0x7f, 0x23, 0x64, 0xe3, 0x01, 0xbc, 0x47, 0x68, 0x82, 0x3d, 0x82, 0x7a, 0x3c, 0x4f, 0xc3, 0xf3,
-	0x15, 0xb5, 0x40, 0x6b, 0xaf, 0x45, 0xca, 0x43, 0x62, 0xf5, 0x2b, 0x9e, 0x12, 0x22, 0x7e, 0x8b,
-	0x64, 0x7e, 0x1e, 0xce, 0x78, 0xaf, 0x41, 0x7a, 0x2a, 0x3d, 0x3c, 0x3d, 0x95, 0xec, 0x7e, 0xc5,
-	0xd3, 0x1a, 0xec, 0x19, 0x74, 0xa3, 0x79, 0x10, 0x9e, 0xaf, 0xc6, 0xfa, 0x8c, 0x4d, 0x67, 0x98,
-	0xea, 0x45, 0x14, 0xe5, 0xc7, 0x3a, 0x51, 0x91, 0xc1, 0xf6, 0xa1, 0x4d, 0x81, 0xcb, 0xd2, 0x11,
-	0xbd, 0x26, 0x1d, 0x75, 0xb2, 0xd8, 0x65, 0xe6, 0x31, 0xea, 0xd6, 0x2c, 0x27, 0x0f, 0x9b, 0x59,
-	0x3d, 0xb8, 0xff, 0x35, 0x35, 0x3e, 0x12, 0xb9, 0x9b, 0xf1, 0xd9, 0x81, 0x5a, 0x11, 0x1a, 0x96,
-	0xc1, 0x3e, 0x5a, 0x46, 0x91, 0x9f, 0x84, 0x94, 0x60, 0xa9, 0xc2, 0x76, 0xa1, 0x81, 0x78, 0xcc,
-	0x93, 0x95, 0x82, 0xe4, 0x7a, 0x6d, 0xad, 0xc4, 0xee, 0xe6, 0xd5, 0x86, 0x83, 0xaa, 0x8d, 0x09,
-	0x55, 0xf5, 0xf6, 0x47, 0x68, 0x53, 0x6a, 0xc3, 0x89, 0x4f, 0x83, 0x4c, 0x42, 0x75, 0xa7, 0xd0,
-	0x15, 0xc3, 0x82, 0xb8, 0x5f, 0xf1, 0x4a, 0xea, 0x6c, 0x7b, 0x1d, 0x0f, 0x39, 0x44, 0xae, 0x01,
-	0xe3, 0xf3, 0x0c, 0x0c, 0xb1, 0x9c, 0x4c, 0xb8, 0x10, 0x04, 0x86, 0x9d, 0x27, 0x7e, 0x24, 0xd9,
-	0xec, 0x19, 0x38, 0x98, 0x50, 0x1e, 0x8c, 0xcb, 0x63, 0xb1, 0x3c, 0x72, 0x10, 0x82, 0x7e, 0xc5,
-	0xeb, 0x4a, 0xd5, 0x53, 0xd5, 0xdf, 0x98, 0x7e, 0xdd, 0x1e, 0xff, 0xa8, 0x02, 0xe4, 0x6d, 0x78,
-	0x73, 0xf2, 0x7f, 0x0f, 0x6d, 0x4a, 0x90, 0xa0, 0xec, 0xad, 0x14, 0x06, 0x59, 0x6d, 0xc9, 0xa4,
-	0x62, 0x1d, 0xb6, 0xa6, 0x59, 0x8e, 0x57, 0xec, 0x11, 0xd4, 0xde, 0xcf, 0xfd, 0xa4, 0xbc, 0x24,
-	0x8e, 0x79, 0x7a, 0x88, 0x4c, 0x44, 0x8b, 0xa4, 0xec, 0x69, 0x8e, 0x96, 0x45, 0x8a, 0xb7, 0xb4,
-	0x22, 0xae, 0x83, 0xbe, 0x14, 0x15, 0xe1, 0xfa, 0x52, 0x4e, 0x30, 0xda, 0x72, 0x0a, 0x90, 0x4e,
-	0x16, 0x3a, 0x9d, 0xa9, 0xc8, 0x91, 0x46, 0xcb, 0xee, 0x11, 0xd4, 0x13, 0x2e, 0xc2, 0x69, 0x5c,
-	0xea, 0x1c, 0x8f, 0x58, 0x08, 0xb4, 0x14, 0xb2, 0x87, 0x60, 0x05, 0x89, 0x7f, 0xa9, 0x60, 0x92,
-	0x3b, 0xf1, 0x45, 0xe2, 0x5f, 0xf6, 0x2b, 0x1e, 0x09, 0xd8, 0x17, 0x60, 0x8b, 0x05, 0x9f, 0xa4,
-	0x7e, 0xaa, 0xfb, 0x44, 0x5e, 0x3a, 0x52, 0x4c, 0xbc, 0x54, 0x2b, 0xb0, 0xaf, 0x00, 0x96, 0x71,
-	0xa6, 0xde, 0x2c, 0xa4, 0xeb, 0x6d, 0xc6, 0xee, 0x57, 0xbc, 0x82, 0x52, 0xb1, 0x33, 0xfe, 0xad,
-	0xa0, 0xf9, 0x98, 0xbe, 0xf8, 0x12, 0x1a, 0x65, 0x54, 0x9c, 0xb5, 0x5a, 0xa7, 0xd4, 0x29, 0x15,
-	0xe6, 0x96, 0x21, 0x01, 0xd2, 0x5d, 0xc3, 0xe3, 0x11, 0xd4, 0x30, 0xb3, 0x42, 0xa1, 0xd1, 0xc9,
-	0x36, 0xb3, 0xaa, 0x29, 0x29, 0x65, 0x7b, 0xd0, 0xc2, 0x1f, 0x63, 0x59, 0x4f, 0x0a, 0x87, 0x8d,
-	0x4c, 0x59, 0xfa, 0x8e, 0x31, 0x46, 0x19, 0xc5, 0xfe, 0x00, 0x1d, 0x99, 0x6e, 0x7d, 0x4a, 0x42,
-	0xb2, 0x59, 0x80, 0x24, 0x3b, 0xd7, 0x4e, 0x0a, 0x34, 0xde, 0x86, 0x28, 0xe8, 0x73, 0xc5, 0xe1,
-	0x86, 0x28, 0xe5, 0xb7, 0x05, 0x19, 0xc5, 0xbe, 0xba, 0x82, 0xd8, 0xad, 0x12, 0x62, 0xd9, 0xa1,
-	0x1c, 0xb7, 0x6f, 0xae, 0xc1, 0xed, 0xf6, 0x1a, 0x6e, 0xf9, 0x5d, 0xd7, 0xa3, 0xd7, 0x06, 0xc8,
-	0xe7, 0xad, 0xfb, 0x4f, 0x03, 0x1a, 0x7a, 0x66, 0xde, 0xb8, 0x7a, 0x19, 0x58, 0x97, 0x61, 0x2c,
-	0xe7, 0x9b, 0xe5, 0xd1, 0x6f, 0xe4, 0xa5, 0x21, 0x17, 0x84, 0x96, 0xe5, 0xd1, 0x6f, 0xf6, 0x29,
-	0xd4, 0x67, 0x73, 0x21, 0x14, 0x3e, 0x96, 0xa7, 0x28, 0xf6, 0x19, 0x74, 0x26, 0xcb, 0x24, 0xe1,
-	0xb1, 0xde, 0x61, 0xb5, 0xad, 0xea, 0x76, 0xdb, 0x6b, 0x2b, 0xa6, 0x5c, 0x57, 0x0f, 0xa1, 0xa5,
-	0x3c, 0x88, 0x71, 0xf1, 0xc8, 0xe7, 0x14, 0x48, 0xd6, 0xd0, 0x8f, 0xb8, 0xbb, 0x03, 0x9d, 0xd2,
-	0xe0, 0x67, 0x77, 0xc1, 0x8e, 0xf9, 0xa5, 0x54, 0x97, 0x2e, 0x37, 0x62, 0x7e, 0x49, 0xba, 0xaf,
-	0xa1, 0x55, 0x98, 0xf4, 0xe8, 0x00, 0x6a, 0x8d, 0xcf, 0x13, 0x7f, 0x1a, 0xf1, 0x38, 0x55, 0xea,
-	0x6d, 0x64, 0xbe, 0x54, 0x3c, 0x34, 0xb7, 0xf0, 0xa7, 0x7c, 0x1c, 0x2f, 0x23, 0x15, 0x69, 0x03,
-	0xe9, 0xe1, 0x32, 0x72, 0x9f, 0x42, 0xa7, 0x34, 0xa1, 0xd9, 0x03, 0x30, 0xf4, 0x6b, 0xea, 0x4a,
-	0x51, 0x7b, 0x86, 0x70, 0xff, 0xac, 0xdf, 0x35, 0xe8, 0xc5, 0x7a, 0x72, 0xab, 0xa5, 0xe4, 0xae,
-	0xc5, 0x6d, 0x92, 0xb8, 0x18, 0xf7, 0x23, 0xb0, 0x75, 0x89, 0xb3, 0xbb, 0x60, 0x46, 0xfa, 0xe2,
-	0x66, 0x5e, 0xd0, 0x66, 0x24, 0xdc, 0x4d, 0xd8, 0x58, 0x7b, 0x0e, 0xb8, 0xcf, 0x60, 0xf3, 0xca,
-	0xae, 0x67, 0x9f, 0xe8, 0x27, 0x34, 0xe6, 0xa0, 0xaa, 0xdf, 0xcc, 0x8e, 0x7c, 0x33, 0x9b, 0xc4,
-	0xc3, 0x9f, 0x2e, 0x87, 0x66, 0xb6, 0xf0, 0x31, 0x82, 0xcb, 0x8b, 0x30, 0xc5, 0x3d, 0x24, 0x74,
-	0x04, 0xc4, 0x18, 0x04, 0x02, 0x85, 0xef, 0x67, 0xfe, 0xe4, 0x3b, 0x12, 0x4a, 0xff, 0x6d, 0x62,
-	0xa0, 0xf0, 0x01, 0x80, 0xaa, 0xc4, 0x79, 0x82, 0xd5, 0x42, 0xd1, 0xe5, 0x1c, 0x55, 0x92, 0x2a,
-	0x75, 0x18, 0xab, 0x9e, 0xc2, 0x88, 0x07, 0x75, 0x7d, 0x5e, 0x91, 0x0d, 0xa2, 0x07, 0x81, 0xeb,
-	0x40, 0xb7, 0x3c, 0x83, 0xdd, 0x27, 0x60, 0xeb, 0x11, 0x8b, 0xdf, 0x10, 0x34, 0x7f, 0x8d, 0xc2,
-	0xbc, 0xa4, 0x34, 0x11, 0xdb, 0xb5, 0xa1, 0x2e, 0xfb, 0xd9, 0xad, 0x83, 0x85, 0x1d, 0xea, 0xfe,
-	0xcb, 0x94, 0x4f, 0x6f, 0xbd, 0x1d, 0x7e, 0x4b, 0x29, 0x4a, 0xf5, 0x57, 0x48, 0x37, 0x47, 0x98,
-	0x9a, 0x4c, 0x0a, 0x31, 0x91, 0x94, 0x02, 0x15, 0xb2, 0x24, 0x90, 0x4b, 0xb1, 0xab, 0x50, 0x25,
-	0x51, 0xc8, 0x42, 0x18, 0x4f, 0x7b, 0x56, 0x29, 0x0b, 0x61, 0x3c, 0xc5, 0x22, 0x90, 0xf9, 0x9d,
-	0x5c, 0xf0, 0xc9, 0x77, 0x34, 0xb1, 0x6c, 0x0f, 0x88, 0xf5, 0x1c, 0x39, 0xa8, 0x20, 0x73, 0x2c,
-	0x15, 0xea, 0x52, 0x81, 0x58, 0x52, 0xe1, 0x3e, 0x48, 0xf5, 0x71, 0xb6, 0x2a, 0x6c, 0x4f, 0x62,
-	0x86, 0x21, 0xa2, 0x58, 0x9e, 0x27, 0xb1, 0x2d, 0xc5, 0xc4, 0x21, 0xf1, 0x2e, 0xdc, 0xa2, 0xd1,
-	0x39, 0x16, 0x61, 0x3c, 0xe1, 0xe3, 0x89, 0xbf, 0x48, 0x97, 0x89, 0x9c, 0x32, 0x55, 0x6f, 0x93,
-	0x44, 0x23, 0x94, 0x3c, 0x97, 0x02, 0xf7, 0x67, 0x03, 0x6a, 0x12, 0x25, 0x17, 0xea, 0x61, 0x8c,
-	0xd5, 0xaa, 0xaa, 0x52, 0xce, 0x6d, 0xfa, 0xb6, 0xf4, 0x94, 0x84, 0x3d, 0x06, 0x5b, 0x59, 0x0c,
-	0xd4, 0xd3, 0xb8, 0xa8, 0x95, 0xc9, 0xd8, 0x63, 0x68, 0xd2, 0xdc, 0x9e, 0xc9, 0x17, 0xeb, 0x5a,
-	0x91, 0xdb, 0x91, 0xee, 0x82, 0x2d, 0xfa, 0x56, 0xb1, 0xae, 0xdf, 0x29, 0xf4, 0xb9, 0x72, 0x0a,
-	0x90, 0x4f, 0x7a, 0xd6, 0xc3, 0x45, 0x24, 0x5f, 0x2e, 0x06, 0x45, 0xae, 0x49, 0xfc, 0xd6, 0x53,
-	0x63, 0xfb, 0x03, 0x1b, 0xca, 0x53, 0x72, 0xd7, 0x83, 0x76, 0x71, 0x0b, 0xfc, 0x2a, 0x36, 0x4f,
-	0x01, 0xf2, 0x0d, 0xf1, 0xab, 0x58, 0xfc, 0x13, 0xd4, 0xe5, 0xc3, 0x1b, 0xbf, 0x71, 0x2f, 0xb8,
-	0x9f, 0xa4, 0xef, 0xb9, 0xaf, 0x5b, 0x3d, 0x67, 0xe0, 0x5d, 0x69, 0x18, 0xf1, 0xf9, 0x32, 0x55,
-	0x2d, 0xaf, 0x49, 0x17, 0xc0, 0xd6, 0x4b, 0x08, 0x7b, 0x33, 0xdf, 0x2d, 0xee, 0x3e, 0x74, 0xcb,
-	0xeb, 0x09, 0xc7, 0x6a, 0x28, 0xc6, 0x85, 0xc2, 0x96, 0x7e, 0xb7, 0x43, 0x31, 0xca, 0x78, 0xee,
-	0x37, 0xe0, 0xac, 0x2f, 0xa8, 0x8f, 0x3b, 0x78, 0x0e, 0x0e, 0x62, 0x58, 0x7c, 0xc4, 0xde, 0x30,
-	0x13, 0xd8, 0x1d, 0x30, 0x22, 0x95, 0x9f, 0x42, 0xd1, 0x18, 0x91, 0x9c, 0xd5, 0xd5, 0x0f, 0x24,
-	0xce, 0x10, 0xee, 0x4f, 0x06, 0x30, 0x09, 0xed, 0xc7, 0x5e, 0x55, 0x9a, 0xe7, 0xe6, 0xda, 0xb2,
-	0x5c, 0x9b, 0xe7, 0xd5, 0xf5, 0x3d, 0x26, 0xfd, 0xb1, 0x3e, 0xec, 0xcf, 0xdf, 0x0c, 0x70, 0xb0,
-	0x2c, 0xfe, 0x3f, 0xbc, 0xf9, 0xbb, 0x01, 0xec, 0xea, 0xd7, 0x04, 0xfb, 0x1c, 0xcc, 0x28, 0x56,
-	0x13, 0xf6, 0x76, 0x96, 0xee, 0xb5, 0x0f, 0x0e, 0x33, 0x8a, 0xd9, 0x13, 0x30, 0x13, 0xfd, 0xd7,
-	0xd1, 0x9d, 0xc2, 0x63, 0x6a, 0x5d, 0x35, 0x21, 0x9b, 0x41, 0xac, 0x90, 0xba, 0x9d, 0xbd, 0x9f,
-	0xd6, 0x15, 0x83, 0xf8, 0xb0, 0x0a, 0x46, 0xbc, 0x73, 0x0f, 0xac, 0x51, 0x18, 0x70, 0xd6, 0x84,
-	0xda, 0xbb, 0xfe, 0xe0, 0xec, 0xc8, 0xa9, 0xe0, 0xcf, 0xc3, 0x57, 0x07, 0xcf, 0x4f, 0x1c, 0x63,
-	0xe7, 0x0c, 0xac, 0xb3, 0xd5, 0x82, 0xb3, 0x16, 0x34, 0x06, 0xc3, 0x6f, 0x0f, 0x5e, 0x0d, 0x5e,
-	0x38, 0x15, 0x66, 0x83, 0x75, 0x7a, 0xf0, 0x6e, 0xe8, 0x18, 0xf8, 0xcb, 0x7b, 0xf3, 0xe6, 0xc4,
-	0x31, 0x19, 0x40, 0xfd, 0x64, 0x38, 0x38, 0xee, 0x9f, 0x39, 0x55, 0xfc, 0x7d, 0x38, 0x18, 0xf5,
-	0xdf, 0x9c, 0x3a, 0x16, 0xda, 0xa2, 0x3f, 0x67, 0x9c, 0x1a, 0x2a, 0x9f, 0x0c, 0x86, 0xc7, 0x4e,
-	0x7d, 0xe7, 0x3f, 0x06, 0x34, 0xb3, 0x3d, 0xc0, 0x3a, 0xd0, 0x7c, 0x87, 0x03, 0x16, 0x23, 0x77,
-	0x2a, 0x48, 0x1e, 0xe2, 0x40, 0x25, 0xd2, 0x60, 0x0c, 0xba, 0xef, 0xb2, 0xf9, 0x1d, 0xf9, 0x29,
-	0x77, 0x4c, 0xe4, 0x1d, 0x66, 0x23, 0x9b, 0x78, 0xd5, 0x4c, 0x6f, 0x94, 0xfa, 0x33, 0x4e, 0x3c,
-	0x2b, 0xd3, 0xcb, 0x79, 0x35, 0xb6, 0x09, 0x1d, 0xd2, 0x93, 0xe9, 0xe3, 0x81, 0x53, 0x47, 0x16,
-	0xa9, 0x65, 0xac, 0x06, 0xeb, 0xca, 0x91, 0x72, 0x30, 0x4d, 0x38, 0x0f, 0x1c, 0x9b, 0x6d, 0x40,
-	0x0b, 0xe9, 0xfd, 0xdf, 0xa1, 0x57, 0xc2, 0x69, 0xa2, 0x97, 0xc8, 0xf8, 0xfa, 0xe5, 0x7c, 0x16,
-	0x38, 0xf0, 0xbe, 0x4e, 0x7f, 0x1e, 0x7e, 0xfd, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc4, 0xb3,
-	0xf7, 0xcc, 0x4a, 0x14, 0x00, 0x00,
+	// 2193 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcd, 0x6f, 0xdb, 0xc8,
+	0x15, 0x17, 0xa9, 0x2f, 0xea, 0x49, 0xb6, 0xe9, 0xc9, 0xee, 0x86, 0x69, 0xf3, 0x61, 0x70, 0x9b,
+	0xac, 0x93, 0x6c, 0x9d, 0x6e, 0xb6, 0xc1, 0x16, 0x08, 0x0a, 0xd4, 0x1f, 0xb2, 0xa5, 0x3a, 0x96,
+	0xdc, 0x91, 0x9d, 0xa0, 0x27, 0x82, 0x11, 0xc7, 0x32, 0xb1, 0x22, 0xa5, 0xe5, 0x50, 0xf1, 0xfa,
+	0xd8, 0x73, 0xd1, 0x6b, 0xd1, 0xcb, 0xde, 0x0a, 0xb4, 0x87, 0x5e, 0xf7, 0x7f, 0xe8, 0x5f, 0xd2,
+	0x5b, 0xff, 0x87, 0xe2, 0xbd, 0xe1, 0xa7, 0xec, 0x78, 0xd3, 0xa2, 0x05, 0x7a, 0xe3, 0xfb, 0x98,
+	0x99, 0xf7, 0x7e, 0xef, 0x6b, 0x86, 0x00, 0x13, 0x37, 0x10, 0x5b, 0xf3, 0x68, 0x16, 0xcf, 0x58,
+	0xd5, 0x9d, 0xfb, 0x3f, 0x6a, 0x8b, 0x28, 0x9a, 0x45, 0x8a, 0x63, 0x3f, 0x02, 0xe3, 0x78, 0x26,
+	0xfd, 0xd8, 0x9f, 0x85, 0xac, 0x03, 0xda, 0xb7, 0x96, 0xb6, 0xa1, 0x6d, 0xd6, 0xb9, 0xf6, 0x2d,
+	0x52, 0x97, 0x96, 0xae, 0xa8, 0x4b, 0xfb, 0x0f, 0x1a, 0xd4, 0x8f, 0x7d, 0x31, 0x16, 0xec, 0x1e,
+	0xd4, 0xe2, 0xcb, 0xb9, 0x20, 0xc5, 0xd5, 0xe7, 0xad, 0x2d, 0x77, 0xee, 0x6f, 0x9d, 0x5c, 0xce,
+	0x05, 0x27, 0x36, 0x7b, 0x0c, 0xc6, 0x3c, 0xd9, 0x90, 0x56, 0xb7, 0x9f, 0xaf, 0x90, 0x4a, 0x7a,
+	0x0a, 0xcf, 0xc4, 0xb8, 0x93, 0xf4, 0x3d, 0x61, 0x55, 0x0b, 0x3b, 0x8d, 0x7c, 0x4f, 0x70, 0x62,
+	0xb3, 0x1f, 0x43, 0xeb, 0xdc, 0x95, 0x4e, 0x30, 0x7b, 0x27, 0x3c, 0xab, 0xb6, 0xa1, 0x6d, 0x1a,
+	0xdc, 0x38, 0x77, 0xe5, 0x11, 0xd2, 0xf6, 0xef, 0x74, 0xa8, 0xe1, 0xd7, 0x0f, 0x99, 0xf3, 0x29,
+	0xd4, 0x65, 0xec, 0x46, 0xf1, 0xf5, 0xb6, 0x28, 0x19, 0x7b, 0x00, 0x55, 0x11, 0x7a, 0x56, 0xf5,
+	0x3a, 0x15, 0x94, 0xb0, 0xbb, 0xd0, 0x9a, 0x47, 0xb3, 0x60, 0x46, 0x5e, 0x29, 0x53, 0x72, 0x06,
+	0xdb, 0x84, 0xc6, 0xd8, 0x95, 0xf1, 0x54, 0x58, 0x75, 0x32, 0xc2, 0xa4, 0x1d, 0xd0, 0xba, 0xad,
+	0x5d, 0xe2, 0xf3, 0x44, 0x8e, 0x2e, 0xcd, 0xa7, 0xee, 0xa5, 0x88, 0x1c, 0xdf, 0xb3, 0x1a, 0x1b,
+	0xda, 0x66, 0x87, 0x1b, 0x8a, 0xd1, 0xf7, 0xec, 0x67, 0xd0, 0x50, 0xea, 0xcc, 0x80, 0xda, 0x60,
+	0x38, 0xe8, 0x9a, 0x15, 0xd6, 0x01, 0xe3, 0xb0, 0x3f, 0x38, 0x18, 0xf5, 0xf7, 0xba, 0xa6, 0xc6,
+	0x56, 0xa0, 0xf5, 0x9b, 0xd3, 0x6e, 0x77, 0x40, 0xa4, 0x6e, 0x1f, 0x42, 0xfb, 0xc0, 0x0d, 0x04,
+	0x17, 0xdf, 0x2c, 0x84, 0x8c, 0xd9, 0x7d, 0xd0, 0xe7, 0xd2, 0xd2, 0x36, 0xaa, 0x9b, 0xed, 0xe7,
+	0xab, 0xca, 0x09, 0xda, 0x9a, 0x8b, 0x6f, 0xb8, 0x3e, 0x97, 0xec, 0x2e, 0xe8, 0x13, 0x69, 0xe9,
+	0x24, 0xef, 0x90, 0x3c, 0x59, 0xcd, 0xf5, 0x89, 0xb4, 0x07, 0xd0, 0x51, 0xa4, 0x9c, 0xcf, 0x42,
+	0x29, 0xd8, 0x83, 0xc2, 0x6e, 0x6b, 0xa5, 0xdd, 0xe4, 0x9c, 0xb6, 0xbb, 0x57, 0xd8, 0x6e, 0xa5,
+	0xb0, 0x1d, 0x8a, 0x27, 0xd2, 0x3e, 0x85, 0x56, 0x76, 0x7c, 0xd9, 0x6f, 0xad, 0xec, 0x37, 0x7b,
+	0x0a, 0x4d, 0x77, 0x8c, 0x40, 0xa6, 0xbb, 0xad, 0x17, 0x8e, 0xdb, 0x26, 0x09, 0x4f, 0x35, 0xec,
+	0xd7, 0x00, 0xb9, 0x1d, 0x3f, 0xb8, 0x6f, 0x24, 0xe4, 0x62, 0x1a, 0x5f, 0xb7, 0x2f, 0x27, 0x09,
+	0x4f, 0x35, 0xec, 0x23, 0x68, 0x26, 0x68, 0xb0, 0xdb, 0xd0, 0xc4, 0x92, 0xc9, 0xb7, 0x6c, 0x20,
+	0xd9, 0xf7, 0xd8, 0xe3, 0x65, 0x43, 0xd7, 0x32, 0xb7, 0x97, 0xcd, 0x1c, 0x80, 0x91, 0xa2, 0x71,
+	0xe3, 0x7e, 0x65, 0x03, 0xd7, 0x8a, 0x30, 0x96, 0xcc, 0xfb, 0xae, 0x0a, 0x9d, 0x22, 0x20, 0xe8,
+	0xb9, 0x3a, 0xab, 0xe0, 0xb9, 0x62, 0xf4, 0x3d, 0xf6, 0x02, 0x60, 0xea, 0xcb, 0xd8, 0xc1, 0x73,
+	0x64, 0x92, 0xf9, 0x1f, 0xd1, 0xde, 0xaf, 0x7c, 0x19, 0xe3, 0x0e, 0xef, 0x04, 0x9e, 0x22, 0x7b,
+	0x15, 0xde, 0x42, 0x4d, 0x22, 0xd8, 0x0b, 0x20, 0xc2, 0x39, 0xf7, 0x65, 0x9c, 0x14, 0xc3, 0x27,
+	0xd9, 0xaa, 0x7d, 0x3f, 0xf4, 0xe5, 0xb9, 0xf0, 0xd2, 0x75, 0x06, 0xaa, 0xf6, 0x7c, 0x19, 0xb3,
+	0x67, 0x00, 0x54, 0x46, 0x74, 0x1c, 0x95, 0x40, 0x9a, 0x7f, 0x23, 0x64, 0xe3, 0x02, 0x3c, 0x47,
+	0xa6, 0x04, 0x7b, 0x08, 0x8d, 0x70, 0x16, 0xfb, 0x67, 0x97, 0x54, 0x02, 0xed, 0xe7, 0x6d, 0x52,
+	0x1e, 0x10, 0xab, 0x57, 0xe1, 0x89, 0x10, 0xe3, 0x37, 0x8f, 0x66, 0x67, 0xfe, 0x54, 0x58, 0xcd,
+	0x0d, 0x2d, 0x87, 0x47, 0xc4, 0xc7, 0x8a, 0xdd, 0xab, 0xf0, 0x54, 0x83, 0xbd, 0x84, 0xd5, 0x60,
+	0xe6, 0xf9, 0x67, 0x97, 0x4e, 0xba, 0xc6, 0xa0, 0x35, 0x2c, 0xa9, 0x45, 0x14, 0xe5, 0xcb, 0x56,
+	0x82, 0x22, 0x83, 0xbd, 0x80, 0x0e, 0x39, 0xae, 0x52, 0x47, 0x5a, 0x2d, 0x5a, 0x6a, 0x66, 0xbe,
+	0x2b, 0xe4, 0xd1, 0xeb, 0xf6, 0x34, 0x27, 0x77, 0x5a, 0x59, 0x3e, 0xd8, 0x7f, 0xc9, 0xe2, 0xa3,
+	0x22, 0x77, 0x73, 0x7c, 0x9e, 0x40, 0xbd, 0x18, 0x1a, 0x96, 0x85, 0x7d, 0xb4, 0x08, 0x02, 0x37,
+	0xf2, 0x09, 0x60, 0xa5, 0xc2, 0xb6, 0xa0, 0x89, 0xf1, 0x98, 0x45, 0x97, 0x56, 0xf5, 0x06, 0xed,
+	0x54, 0x89, 0xdd, 0xc9, 0xb3, 0x0d, 0x1b, 0x55, 0x07, 0x01, 0x4d, 0xf2, 0xed, 0x97, 0xd0, 0x21,
+	0x68, 0xfd, 0xb1, 0x4b, 0x8d, 0x4c, 0x85, 0xea, 0x76, 0xa1, 0x2a, 0x06, 0x05, 0x71, 0xaf, 0xc2,
+	0x4b, 0xea, 0x6c, 0x73, 0x39, 0x1e, 0xaa, 0x89, 0x5c, 0x13, 0x8c, 0xcf, 0xb2, 0x60, 0xc8, 0xc5,
+	0x78, 0x2c, 0xa4, 0xa4, 0x60, 0x18, 0x39, 0xf0, 0x23, 0xc5, 0x66, 0x2f, 0xc1, 0x44, 0x40, 0x85,
+	0xe7, 0x94, 0xdb, 0x62, 0xb9, 0xe5, 0x60, 0x08, 0x7a, 0x15, 0xbe, 0xaa, 0x54, 0x8f, 0xd3, 0xfa,
+	0x7e, 0x0c, 0x75, 0x9a, 0x64, 0x16, 0xd0, 0x8a, 0x5b, 0xb4, 0xa2, 0x1f, 0xbe, 0x73, 0xa7, 0xbe,
+	0x97, 0xf4, 0x44, 0xae, 0x34, 0x30, 0x52, 0x69, 0x25, 0xfd, 0xa9, 0x0a, 0x90, 0x57, 0xec, 0xcd,
+	0x71, 0xfa, 0x39, 0x74, 0x08, 0x4b, 0x49, 0x40, 0x5f, 0x5a, 0x7a, 0xc1, 0xb4, 0x03, 0x11, 0x2b,
+	0xfc, 0x31, 0x65, 0xdb, 0x93, 0x2c, 0x1c, 0x97, 0xec, 0x21, 0xd4, 0xdf, 0xce, 0xdc, 0xa8, 0x3c,
+	0x4f, 0x0e, 0x44, 0xbc, 0x83, 0x4c, 0x0c, 0x2c, 0x49, 0xd9, 0xb3, 0x3c, 0xb0, 0xb5, 0x82, 0x03,
+	0x07, 0x22, 0xc6, 0xc9, 0xd1, 0x53, 0xa2, 0x62, 0x64, 0x3f, 0x57, 0xcd, 0x8e, 0x06, 0xa2, 0x55,
+	0x2f, 0xec, 0x8d, 0x88, 0xd0, 0x9a, 0x8a, 0xea, 0x7e, 0xf8, 0x8d, 0x45, 0x16, 0x09, 0xe9, 0x4f,
+	0xc2, 0x52, 0x91, 0x71, 0x62, 0x61, 0x4e, 0x28, 0x21, 0x7b, 0x00, 0x35, 0x2f, 0x72, 0x2f, 0x92,
+	0x88, 0xaa, 0xf1, 0xb9, 0x17, 0xb9, 0x17, 0xbd, 0x0a, 0x27, 0x01, 0x7b, 0x0a, 0x86, 0x9c, 0x8b,
+	0x71, 0xec, 0xc6, 0x69, 0x49, 0xa9, 0x43, 0x47, 0x09, 0x13, 0x0f, 0x4d, 0x15, 0xd8, 0x17, 0x00,
+	0x8b, 0x30, 0x53, 0x6f, 0x15, 0xe0, 0x3a, 0xcd, 0xd8, 0xbd, 0x0a, 0x2f, 0x28, 0x15, 0x8b, 0xe8,
+	0x1f, 0x49, 0x68, 0x3e, 0xa4, 0x84, 0x3e, 0x87, 0x66, 0x39, 0x2a, 0xe6, 0x52, 0x59, 0x10, 0x74,
+	0x89, 0x0a, 0xb3, 0xcb, 0x21, 0x01, 0xd2, 0x5d, 0x8a, 0xc7, 0x43, 0xa8, 0x23, 0xb2, 0xd2, 0xaa,
+	0x15, 0xbc, 0x44, 0x28, 0x93, 0xf4, 0x53, 0x52, 0xf6, 0x1c, 0xda, 0xf8, 0xe1, 0xa8, 0x7c, 0xb2,
+	0xea, 0x05, 0x1f, 0x51, 0x59, 0xd9, 0x8e, 0x3e, 0x06, 0x19, 0xc5, 0x7e, 0x01, 0x2b, 0x0a, 0xee,
+	0x74, 0x95, 0x0a, 0xc9, 0x7a, 0x21, 0x24, 0xd9, 0xba, 0x4e, 0x54, 0xa0, 0xf1, 0x34, 0x8c, 0x42,
+	0xba, 0xae, 0xd8, 0x07, 0x31, 0x4a, 0xf9, 0x69, 0x5e, 0x46, 0xb1, 0x2f, 0xae, 0x44, 0xec, 0x56,
+	0x29, 0x62, 0xd9, 0xa2, 0x3c, 0x6e, 0x5f, 0x5d, 0x13, 0xb7, 0x8f, 0x97, 0xe2, 0x96, 0x9f, 0x95,
+	0xab, 0xfe, 0x9b, 0x35, 0x98, 0x06, 0xba, 0x03, 0x90, 0x77, 0x71, 0xfb, 0x6f, 0x1a, 0x34, 0x93,
+	0xef, 0x9b, 0x07, 0x3a, 0x83, 0xda, 0x85, 0x1f, 0xaa, 0xae, 0x59, 0xe3, 0xf4, 0x8d, 0xbc, 0xd8,
+	0x17, 0x92, 0x02, 0x5b, 0xe3, 0xf4, 0xcd, 0x3e, 0x81, 0xc6, 0x74, 0x26, 0x65, 0x12, 0xca, 0x1a,
+	0x4f, 0x28, 0xf6, 0x29, 0xac, 0x8c, 0x17, 0x51, 0x24, 0xc2, 0x74, 0x32, 0xd6, 0x37, 0xaa, 0x9b,
+	0x1d, 0xde, 0x49, 0x98, 0x6a, 0x08, 0x3e, 0x80, 0x76, 0x62, 0x41, 0x88, 0xe3, 0x4c, 0x5d, 0xd2,
+	0x40, 0xb1, 0x06, 0x6e, 0x20, 0xec, 0x27, 0xb0, 0x52, 0x1a, 0x27, 0xec, 0x0e, 0x18, 0xa1, 0xb8,
+	0x50, 0xea, 0xca, 0xe4, 0x66, 0x28, 0x2e, 0x48, 0xf7, 0x08, 0xda, 0x85, 0xf9, 0x81, 0x06, 0xa0,
+	0x96, 0x73, 0x16, 0xb9, 0x93, 0x40, 0x84, 0x71, 0xa2, 0xde, 0x41, 0xe6, 0x7e, 0xc2, 0xc3, 0xed,
+	0xe6, 0xee, 0x44, 0x38, 0xe1, 0x22, 0x48, 0x3c, 0x6d, 0x22, 0x3d, 0x58, 0x04, 0xf6, 0x33, 0x58,
+	0x29, 0xf5, 0x7d, 0x76, 0x1f, 0xb4, 0xf4, 0x8e, 0x76, 0x25, 0xff, 0xb9, 0x26, 0xed, 0x5f, 0xa7,
+	0xb7, 0x25, 0xb4, 0x62, 0x19, 0xdc, 0x6a, 0x09, 0xdc, 0x25, 0xbf, 0xf5, 0x8d, 0xea, 0x92, 0xdf,
+	0x0f, 0xc1, 0x48, 0xab, 0x81, 0xdd, 0x01, 0x3d, 0x48, 0x0f, 0x6e, 0xe5, 0xb9, 0xaf, 0x07, 0xd2,
+	0x5e, 0x87, 0xb5, 0xa5, 0x4b, 0x86, 0xfd, 0x12, 0xd6, 0xaf, 0xdc, 0x20, 0xd8, 0x47, 0xe9, 0xc5,
+	0x1c, 0x31, 0xa8, 0xa6, 0x37, 0x71, 0x53, 0xdd, 0xc4, 0x75, 0xe2, 0xe1, 0xa7, 0x2d, 0xa0, 0x95,
+	0x5d, 0x23, 0xd0, 0x83, 0x8b, 0x73, 0x3f, 0xc6, 0xe9, 0x26, 0x53, 0x0f, 0x88, 0xd1, 0xf7, 0x24,
+	0x0a, 0xdf, 0x4e, 0xdd, 0xf1, 0xd7, 0x24, 0x54, 0xf6, 0x1b, 0xc4, 0x40, 0xe1, 0x7d, 0x80, 0x24,
+	0x69, 0x67, 0x11, 0x66, 0x0b, 0x79, 0x97, 0x73, 0x92, 0x94, 0x4c, 0xa0, 0x43, 0x5f, 0xd3, 0x86,
+	0x8d, 0xf1, 0xa0, 0x06, 0x91, 0x67, 0x64, 0x93, 0xe8, 0xbe, 0x67, 0x9b, 0xb0, 0x5a, 0x6e, 0xd7,
+	0xf6, 0x63, 0x30, 0xd2, 0x6e, 0x8c, 0x2f, 0x13, 0x6a, 0xd5, 0x5a, 0xa1, 0xb5, 0x12, 0x4c, 0xc4,
+	0xb6, 0x0d, 0x68, 0xa8, 0xd2, 0xb7, 0x1b, 0x50, 0xc3, 0x62, 0xb6, 0xff, 0xae, 0xab, 0x0b, 0x7d,
+	0x3a, 0x48, 0x7e, 0x42, 0x10, 0xc5, 0xe9, 0xdb, 0x66, 0x35, 0x8f, 0x30, 0x72, 0xb9, 0x12, 0x22,
+	0x90, 0x04, 0x41, 0xe2, 0xb2, 0x22, 0x90, 0x4b, 0xbe, 0x27, 0xae, 0x2a, 0xa2, 0x80, 0x82, 0x1f,
+	0x4e, 0xac, 0x5a, 0x09, 0x05, 0x3f, 0x9c, 0x60, 0x12, 0x28, 0x7c, 0xc7, 0xe7, 0x62, 0xfc, 0x35,
+	0x35, 0x37, 0x83, 0x03, 0xb1, 0x76, 0x91, 0x83, 0x0a, 0x0a, 0x63, 0xa5, 0xd0, 0x50, 0x0a, 0xc4,
+	0x52, 0x0a, 0xf7, 0x40, 0xa9, 0x3b, 0xd9, 0x54, 0x31, 0xb8, 0x8a, 0x19, 0xba, 0x88, 0x62, 0xb5,
+	0x9e, 0xc4, 0x86, 0x12, 0x13, 0x87, 0xc4, 0x5b, 0x70, 0x8b, 0xba, 0xac, 0x23, 0xfd, 0x70, 0x2c,
+	0x9c, 0xb1, 0x3b, 0x8f, 0x17, 0x91, 0x6a, 0x48, 0x55, 0xbe, 0x4e, 0xa2, 0x11, 0x4a, 0x76, 0x95,
+	0xa0, 0x78, 0xb5, 0x86, 0xe2, 0xd5, 0xda, 0xfe, 0x4e, 0x83, 0xba, 0x0a, 0x9f, 0x0d, 0x0d, 0x3f,
+	0xc4, 0x34, 0x4e, 0xd2, 0x55, 0xf5, 0x7e, 0x7a, 0xca, 0xf2, 0x44, 0xc2, 0x1e, 0x81, 0x91, 0x1c,
+	0xe5, 0x59, 0xfa, 0x15, 0xad, 0x4c, 0xc6, 0x1e, 0x41, 0x8b, 0x7a, 0xff, 0x54, 0x5d, 0x90, 0x97,
+	0xb2, 0xdf, 0x08, 0xd2, 0xf2, 0xd8, 0xa0, 0xa7, 0x51, 0xed, 0xfa, 0xb9, 0x44, 0xaf, 0xa3, 0xef,
+	0x75, 0x80, 0x7c, 0x5c, 0x30, 0x0b, 0xa7, 0x99, 0xba, 0x29, 0x69, 0x84, 0x49, 0x4a, 0xe2, 0xdb,
+	0x32, 0xe9, 0xfd, 0xef, 0x19, 0x73, 0x3c, 0x91, 0xb3, 0xa7, 0x69, 0x2b, 0x56, 0xcf, 0xe9, 0x8f,
+	0x97, 0x46, 0xd2, 0x56, 0x17, 0x85, 0x49, 0x33, 0xb6, 0xbf, 0xd7, 0xa0, 0x4e, 0x0c, 0x7c, 0x61,
+	0x0e, 0x86, 0x4e, 0x97, 0xf3, 0x21, 0x37, 0x2b, 0x6c, 0x15, 0xe0, 0x60, 0xfb, 0xa8, 0xeb, 0x74,
+	0x07, 0x7b, 0xdd, 0x3d, 0x53, 0x63, 0xeb, 0xb0, 0x32, 0x18, 0x9e, 0x38, 0xbf, 0x1d, 0x9e, 0x72,
+	0xe7, 0xe4, 0x94, 0x0f, 0x4c, 0x1d, 0x55, 0xde, 0xf0, 0xe1, 0xe0, 0xc0, 0xa1, 0x57, 0x68, 0x15,
+	0x55, 0x86, 0xa7, 0x27, 0xce, 0x70, 0xdf, 0xd9, 0x19, 0x9e, 0x0e, 0xf6, 0x46, 0x66, 0x8d, 0xdd,
+	0x82, 0xb5, 0xe3, 0x7e, 0x77, 0xb7, 0xeb, 0xe0, 0xda, 0x7d, 0xe4, 0x9a, 0x75, 0x66, 0x42, 0xa7,
+	0x3f, 0x78, 0xbd, 0xfd, 0xaa, 0xbf, 0xe7, 0x1c, 0x0d, 0x5f, 0x77, 0xcd, 0x06, 0x1e, 0xdd, 0x1f,
+	0x38, 0xbb, 0xbd, 0xee, 0xee, 0xa1, 0xd9, 0x64, 0x6b, 0xd0, 0xde, 0xdd, 0x1e, 0x9c, 0x38, 0xbb,
+	0xdb, 0xa3, 0x93, 0x57, 0x5d, 0xd3, 0x60, 0x0c, 0x56, 0x8f, 0xb6, 0x5f, 0xed, 0x0f, 0xf9, 0x51,
+	0x37, 0x59, 0xd2, 0xb2, 0xff, 0xaa, 0x41, 0xa7, 0x38, 0x30, 0xff, 0x2b, 0xc8, 0xfd, 0xb4, 0x8c,
+	0xdc, 0xed, 0x2b, 0x63, 0xb9, 0x8c, 0xdd, 0xc3, 0x0f, 0x82, 0xce, 0xfe, 0xb3, 0x06, 0x90, 0xcf,
+	0xe8, 0xff, 0x5d, 0x88, 0xf3, 0x33, 0xfe, 0x23, 0x33, 0x7f, 0x05, 0x0d, 0xf5, 0xf2, 0xc2, 0x9f,
+	0x1c, 0xe7, 0xc2, 0x8d, 0xe2, 0xb7, 0xc2, 0x4d, 0xbb, 0x72, 0xce, 0x40, 0xfb, 0x63, 0x3f, 0x10,
+	0xb3, 0x45, 0x9c, 0x74, 0xe7, 0x94, 0xb4, 0x01, 0x8c, 0xf4, 0x6a, 0x81, 0x6d, 0x34, 0xbf, 0x31,
+	0xd8, 0x2f, 0x60, 0xb5, 0x7c, 0xe9, 0xc0, 0x09, 0xe8, 0x4b, 0xa7, 0xd0, 0x83, 0x14, 0x16, 0x1d,
+	0x5f, 0x8e, 0x32, 0x9e, 0xfd, 0x15, 0x98, 0xcb, 0xd7, 0x8e, 0x0f, 0x5b, 0x78, 0x06, 0x26, 0x26,
+	0x7c, 0xf1, 0x15, 0x73, 0x43, 0xfb, 0x66, 0xb7, 0x41, 0x0b, 0x12, 0xcc, 0x0b, 0x65, 0xac, 0x05,
+	0x6a, 0xac, 0x56, 0xdf, 0x13, 0x0c, 0x4d, 0xe2, 0xcf, 0x30, 0xa6, 0xf2, 0xe3, 0x43, 0x8f, 0x2a,
+	0x8d, 0x5e, 0x7d, 0x43, 0xbb, 0x69, 0xf4, 0x56, 0x97, 0xaf, 0x1c, 0xca, 0x9e, 0xda, 0xfb, 0xed,
+	0xf9, 0xbd, 0x06, 0x26, 0xa6, 0xc1, 0xff, 0x87, 0x35, 0x7f, 0xd4, 0x80, 0x5d, 0x7d, 0x4e, 0xb2,
+	0xcf, 0x40, 0x0f, 0xc2, 0x64, 0x18, 0xe6, 0xcd, 0x69, 0xe9, 0xc5, 0xa9, 0x07, 0x21, 0x7b, 0x0c,
+	0x7a, 0x94, 0xfe, 0x3b, 0x2c, 0xd6, 0xe2, 0xb2, 0x6a, 0x44, 0x7b, 0x7a, 0xa1, 0x55, 0x2d, 0xec,
+	0xb9, 0x0c, 0x03, 0x2a, 0x7a, 0xe1, 0x4e, 0x15, 0xb4, 0xf0, 0xc9, 0x5d, 0xa8, 0xe1, 0xef, 0x45,
+	0xd6, 0x82, 0xfa, 0x9b, 0x5e, 0xff, 0x04, 0xff, 0xaf, 0xb5, 0xa0, 0xbe, 0xf3, 0x6a, 0x7b, 0xf7,
+	0xd0, 0xd4, 0x9e, 0x9c, 0x40, 0x0d, 0xff, 0x1b, 0xb2, 0x36, 0x34, 0x93, 0x3e, 0x65, 0x56, 0xf0,
+	0x4f, 0xdc, 0xf1, 0xf6, 0x9b, 0x81, 0xa9, 0xe1, 0x17, 0x1f, 0x0e, 0x0f, 0x4d, 0x9d, 0x01, 0x34,
+	0x0e, 0x07, 0xfd, 0x83, 0xde, 0x89, 0x59, 0xc5, 0xef, 0x9d, 0xfe, 0xa8, 0x37, 0x3c, 0x36, 0x6b,
+	0xb8, 0x17, 0xfd, 0x9d, 0x33, 0xeb, 0xa8, 0x8c, 0xbf, 0xed, 0xcc, 0xc6, 0x93, 0x7f, 0x6a, 0xd0,
+	0xca, 0x46, 0x36, 0xfe, 0xc0, 0x7b, 0x83, 0xb3, 0x10, 0x3d, 0x37, 0x2b, 0x48, 0xee, 0xe0, 0xec,
+	0x23, 0x52, 0xc3, 0x86, 0xf7, 0x26, 0x1b, 0xb5, 0x81, 0x1b, 0x0b, 0x53, 0x47, 0xde, 0x4e, 0x36,
+	0x5d, 0x89, 0x57, 0xcd, 0xf4, 0x46, 0xb1, 0x3b, 0x15, 0xc4, 0xab, 0x65, 0x7a, 0x39, 0xaf, 0x8e,
+	0x9d, 0x99, 0xf4, 0x14, 0x7c, 0xc2, 0x33, 0x1b, 0xc8, 0x22, 0xb5, 0x8c, 0xd5, 0xc4, 0x86, 0x80,
+	0xa0, 0x6d, 0x4f, 0x22, 0x21, 0x3c, 0xd3, 0xc0, 0x3e, 0x8c, 0xf4, 0x8b, 0x9f, 0xa1, 0x55, 0xd2,
+	0x6c, 0xa1, 0x95, 0xc8, 0xf8, 0x72, 0x7f, 0x36, 0xf5, 0x4c, 0x60, 0x77, 0xc1, 0x42, 0xb2, 0x1f,
+	0xca, 0xc5, 0xd9, 0x99, 0x3f, 0xf6, 0x45, 0x18, 0x1f, 0xb9, 0xb1, 0x88, 0x7c, 0x77, 0x6a, 0xb6,
+	0xdf, 0x36, 0xe8, 0xb7, 0xf2, 0x97, 0xff, 0x1a, 0x00, 0x09, 0x7a, 0x90, 0xb2, 0x76, 0x16, 0x00,
+	0x00,
 }
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrNoKey     = errors.New("no such device key")
+	ErrKeyExists = errors.New("device key already registered")
+	ErrBadKey    = errors.New("not an Ed25519 public key")
+)
+
+// an Ed25519 public key one of a user's devices can log in with instead of
+// their password, so the password doesn't have to be kept on the device
+type DeviceKey struct {
+	User       string
+	Pub        []byte
+	DeviceType []byte
+}
+
+// where users' device keys are kept
+type KeyStore interface {
+	// ErrNoKey if user hasn't registered pub
+	Key(user string, pub []byte) (DeviceKey, error)
+	// ErrKeyExists if pub is already registered, to anyone
+	AddKey(k DeviceKey) error
+	// the user's keys, ordered by Pub
+	Keys(user string) ([]DeviceKey, error)
+	// ErrNoKey if user hasn't registered pub
+	RevokeKey(user string, pub []byte) error
+}
+
+func checkKey(pub []byte) error {
+	if len(pub) != ed25519.PublicKeySize {
+		return ErrBadKey
+	}
+	return nil
+}
+
+// a KeyStore that forgets everything on restart; for tests
+type MemoryKeys struct {
+	mu   sync.Mutex
+	keys map[string]DeviceKey
+}
+
+func NewMemoryKeys() *MemoryKeys {
+	return &MemoryKeys{keys: map[string]DeviceKey{}}
+}
+
+func (m *MemoryKeys) Key(user string, pub []byte) (DeviceKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.keys[string(pub)]
+	if !ok || k.User != user {
+		return DeviceKey{}, ErrNoKey
+	}
+	return k, nil
+}
+
+func (m *MemoryKeys) AddKey(k DeviceKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.keys[string(k.Pub)]; ok {
+		return ErrKeyExists
+	}
+	m.keys[string(k.Pub)] = k
+	return nil
+}
+
+func (m *MemoryKeys) Keys(user string) ([]DeviceKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var ks []DeviceKey
+	for _, k := range m.keys {
+		if k.User == user {
+			ks = append(ks, k)
+		}
+	}
+	sort.Slice(ks, func(i, j int) bool { return bytes.Compare(ks[i].Pub, ks[j].Pub) < 0 })
+	return ks, nil
+}
+
+func (m *MemoryKeys) RevokeKey(user string, pub []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.keys[string(pub)]
+	if !ok || k.User != user {
+		return ErrNoKey
+	}
+	delete(m.keys, string(pub))
+	return nil
+}
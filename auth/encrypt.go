@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	proto "github.com/golang/protobuf/proto"
+
+	api "github.com/cactorium/chesster-server/api"
+)
+
+var (
+	ErrNoEncryption = errors.New("encryption not enabled")
+	ErrDecrypt      = errors.New("couldn't decrypt payload")
+)
+
+func (k *Keys) aead(d Direction) (cipher.AEAD, error) {
+	key := k.ClientEnc
+	if d == FromServer {
+		key = k.ServerEnc
+	}
+	if key == nil {
+		return nil, ErrNoEncryption
+	}
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(b)
+}
+
+// replaces m's payload with an enc_payload holding it encrypted: a random
+// nonce followed by the sealed Message with just the payload set. Sign has
+// to be called afterwards, since the header changes
+func (k *Keys) Encrypt(d Direction, m *api.Message) error {
+	a, err := k.aead(d)
+	if err != nil {
+		return err
+	}
+	inner, err := proto.Marshal(&api.Message{Payload: m.Payload})
+	if err != nil {
+		return err
+	}
+	nonce, err := randomBytes(a.NonceSize())
+	if err != nil {
+		return err
+	}
+	if m.Header == nil {
+		m.Header = &api.Header{}
+	}
+	m.Header.Encrypted = true
+	m.Payload = &api.Message_EncPayload{EncPayload: a.Seal(nonce, nonce, inner, nil)}
+	return nil
+}
+
+// undoes Encrypt, putting back the original payload
+func (k *Keys) Decrypt(d Direction, m *api.Message) error {
+	a, err := k.aead(d)
+	if err != nil {
+		return err
+	}
+	enc := m.GetEncPayload()
+	if !m.GetHeader().GetEncrypted() || len(enc) < a.NonceSize() {
+		return ErrDecrypt
+	}
+	buf, err := a.Open(nil, enc[:a.NonceSize()], enc[a.NonceSize():], nil)
+	if err != nil {
+		return ErrDecrypt
+	}
+	inner := &api.Message{}
+	if err := proto.Unmarshal(buf, inner); err != nil || inner.Header != nil {
+		return ErrDecrypt
+	}
+	if _, ok := inner.Payload.(*api.Message_EncPayload); ok || inner.Payload == nil {
+		return ErrDecrypt
+	}
+	m.Payload = inner.Payload
+	return nil
+}
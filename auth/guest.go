@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"golang.org/x/crypto/curve25519"
+
+	api "github.com/cactorium/chesster-server/api"
+	server "github.com/cactorium/chesster-server/server"
+)
+
+// starts a guest session. There's no password to share, so the secret the
+// keys are derived from comes from an X25519 exchange instead; the rest of
+// the handshake is the same as for users
+func (h *Handler) auth1Guest(req *api.Auth1GuestRequest) (*api.AuthResponse, error) {
+	if len(req.GuestPub) != curve25519.PointSize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad guest public key"}
+	}
+	if len(req.GuestNonce) != NonceSize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad guest nonce"}
+	}
+
+	priv, err := randomBytes(curve25519.ScalarSize)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	// fails for low order points, which would give a secret anyone knows
+	secret, err := curve25519.X25519(priv, req.GuestPub)
+	if err != nil {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad guest public key"}
+	}
+
+	snonce, err := randomBytes(NonceSize)
+	if err != nil {
+		return nil, err
+	}
+	token, err := randomBytes(TokenSize)
+	if err != nil {
+		return nil, err
+	}
+	h.Sessions.add(Session{
+		Token: token,
+		Guest: true,
+		Keys:  DeriveKeys(secret, req.GuestNonce, snonce),
+	}, snonce)
+	return &api.AuthResponse{R: &api.AuthResponse_AuthGuest{AuthGuest: &api.Auth1GuestResponse{
+		ServerPub:    pub,
+		ServerNonce:  snonce,
+		SessionToken: token,
+	}}}, nil
+}
+
+// whether a guest can make a request; they can only finish the handshake,
+// keep their session going, create an account, and watch games
+func guestAllowed(m *api.Message) bool {
+	switch p := m.Payload.(type) {
+	case *api.Message_AuthReq:
+		switch p.AuthReq.R.(type) {
+		case *api.AuthRequest_Auth2, *api.AuthRequest_RefreshToken, *api.AuthRequest_CreateAccount:
+			return true
+		}
+		return false
+	case *api.Message_GameReq:
+		for _, pr := range p.GameReq.Ps {
+			for _, a := range pr.Actions {
+				if _, ok := a.Actions.(*api.PlayerAction_ListGames); !ok {
+					return false
+				}
+			}
+		}
+		for _, g := range p.GameReq.Gs {
+			for _, a := range g.Actions {
+				switch a.Actions.(type) {
+				case *api.GameAction_GameSummary, *api.GameAction_Board, *api.GameAction_History,
+					*api.GameAction_Spectate, *api.GameAction_Unspectate:
+				default:
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
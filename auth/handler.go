@@ -0,0 +1,378 @@
+// Package auth implements the handshake from the README: the client and
+// server prove to each other they know the user's salted password hash, and
+// derive the session's keys from it. Guests have no password, so they share
+// a secret through an X25519 exchange instead, and so do devices logging in
+// with a registered key, which also sign the handshake with it.
+//
+// Handler is both the server's AuthHandler and the Middleware that checks
+// and signs message headers, so it has to be set as both.
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	api "github.com/cactorium/chesster-server/api"
+	server "github.com/cactorium/chesster-server/server"
+)
+
+type Handler struct {
+	Users      UserStore
+	DeviceKeys KeyStore
+	Sessions   *Sessions
+
+	// for making up salts for users that don't exist, so they can't be told
+	// apart from ones that do; see LoadSecret
+	secret []byte
+}
+
+// where the server's own secret is kept, so what's derived from it stays the
+// same across restarts
+type SecretStore interface {
+	// the secret called name; if there isn't one yet s is saved as it and
+	// returned
+	Secret(name string, s []byte) ([]byte, error)
+}
+
+// the handler's secret is made up on the spot, so fake salts change when it
+// restarts until LoadSecret is called
+func NewHandler(users UserStore) (*Handler, error) {
+	secret, err := randomBytes(keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		Users:      users,
+		DeviceKeys: NewMemoryKeys(),
+		Sessions:   NewSessions(),
+		secret:     fakeSaltKey(secret),
+	}, nil
+}
+
+// takes the server's secret from store, making it the first time, so the
+// salts made up for users that don't exist are the same after a restart like
+// the real ones are
+func (h *Handler) LoadSecret(store SecretStore) error {
+	s, err := randomBytes(keySize)
+	if err != nil {
+		return err
+	}
+	if s, err = store.Secret("server", s); err != nil {
+		return err
+	}
+	h.secret = fakeSaltKey(s)
+	return nil
+}
+
+func fakeSaltKey(secret []byte) []byte {
+	k := make([]byte, keySize)
+	// can't fail for this little output
+	io.ReadFull(hkdf.New(sha256.New, secret, nil, []byte("chesster fake salts")), k)
+	return k
+}
+
+func authError(reason string) error {
+	return &server.RequestError{Code: api.InvalidRequest_AUTH_ERROR, Reason: reason}
+}
+
+type sessionKey struct{}
+
+// the session a request was made in, once its header has been checked
+func SessionFrom(ctx context.Context) (Session, bool) {
+	s, ok := ctx.Value(sessionKey{}).(Session)
+	return s, ok
+}
+
+// a context carrying s for SessionFrom, as the middleware passes it on
+func WithSession(ctx context.Context, s Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// looks up the session for a request's token and checks its hmac
+func (h *Handler) verify(m *api.Message) (Session, error) {
+	sess, err := h.Sessions.Get(m.GetHeader().GetToken())
+	if err == ErrSessionExpired {
+		return Session{}, &server.RequestError{Code: api.InvalidRequest_TOKEN_EXPIRED, Reason: "token expired"}
+	} else if err != nil {
+		return Session{}, authError("unknown token")
+	}
+	if !sess.Keys.Verify(FromClient, m) {
+		return Session{}, authError("bad hmac")
+	}
+	return sess, nil
+}
+
+// whether a request starts a handshake, so there's no session to check it
+// against yet
+func startsSession(m *api.Message) bool {
+	switch m.GetAuthReq().GetR().(type) {
+	case *api.AuthRequest_Auth, *api.AuthRequest_AuthGuest:
+		return true
+	}
+	return false
+}
+
+// checks the hmac of every request other than the ones that start a
+// handshake, and that the handshake is done for everything but its
+// acknowledgement. The session is passed on to the handlers; see SessionFrom
+func (h *Handler) Request(ctx context.Context, c *server.Conn, m *api.Message) (context.Context, error) {
+	if startsSession(m) {
+		return ctx, nil
+	}
+	if len(m.GetHeader().GetToken()) == 0 {
+		return ctx, authError("missing token")
+	}
+	sess, err := h.verify(m)
+	if err != nil {
+		return ctx, err
+	}
+	// the request is genuine from here on, so errors are signed
+	ctx = WithSession(ctx, sess)
+	hd := m.Header
+	if len(hd.ClientNonce) != NonceSize {
+		return ctx, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad client nonce"}
+	}
+	if err := h.Sessions.checkReplay(sess.Token, hd.ReqId, hd.ClientNonce, hd.ServerNonce); err == ErrReplayed {
+		return ctx, &server.RequestError{Code: api.InvalidRequest_REPLAYED, Reason: "req_id or nonce reused or too old"}
+	} else if err == ErrNoSession || err == ErrSessionExpired {
+		return ctx, authError("unknown token")
+	} else if err != nil {
+		return ctx, err
+	}
+	if !sess.Authenticated && m.GetAuthReq().GetAuth2() == nil {
+		return ctx, authError("handshake not finished")
+	}
+
+	if sess.Encrypted {
+		if err := sess.Keys.Decrypt(FromClient, m); err != nil {
+			return ctx, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "couldn't decrypt payload"}
+		}
+	} else if hd.Encrypted || m.GetEncPayload() != nil {
+		return ctx, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "encryption not enabled"}
+	}
+	if sess.Guest && !guestAllowed(m) {
+		return ctx, &server.RequestError{Code: api.InvalidRequest_NOT_ALLOWED, Reason: "guests can only spectate"}
+	}
+	return ctx, nil
+}
+
+// signs every reply in a session with its keys, including errors; the replies
+// to Auth1Request and Auth1GuestRequest are signed with the keys of the
+// session they just started.
+// Each reply has a new server nonce, which the next requests have to echo.
+// In encrypted sessions everything after the Auth2Response is encrypted
+func (h *Handler) Response(ctx context.Context, c *server.Conn, req, resp *api.Message) error {
+	var token, cnonce, snonce []byte
+	if a1 := resp.GetAuthResp().GetAuth(); a1 != nil {
+		token = a1.Token
+		if a1.GetPassword() != nil {
+			cnonce = req.GetAuthReq().GetAuth().GetPassword().GetClientNonce()
+			snonce = a1.GetPassword().ServerNonce
+		} else {
+			cnonce = req.GetAuthReq().GetAuth().GetPubKey().GetClientNonce()
+			snonce = a1.GetPubKey().GetServerNonce()
+		}
+	} else if g := resp.GetAuthResp().GetAuthGuest(); g != nil {
+		token = g.SessionToken
+		cnonce = req.GetAuthReq().GetAuthGuest().GetGuestNonce()
+		snonce = g.ServerNonce
+	} else {
+		sess, ok := SessionFrom(ctx)
+		if !ok {
+			return nil
+		}
+		token = sess.Token
+		cnonce = req.GetHeader().GetClientNonce()
+	}
+	sess, err := h.Sessions.Get(token)
+	if err != nil {
+		// the request may have ended its own session, like revoking the key
+		// it logged in with; the reply is still signed with its keys
+		s, ok := SessionFrom(ctx)
+		if !ok || string(s.Token) != string(token) {
+			return nil
+		}
+		sess = s
+	} else if snonce == nil {
+		if snonce, err = h.Sessions.issueNonce(token); err == ErrNoSession || err == ErrSessionExpired {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	resp.Header.Token = token
+	resp.Header.ClientNonce = cnonce
+	resp.Header.ServerNonce = snonce
+	if sess.Encrypted && resp.GetAuthResp().GetAuth2() == nil {
+		if err := sess.Keys.Encrypt(FromServer, resp); err != nil {
+			return err
+		}
+	}
+	return sess.Keys.Sign(FromServer, resp)
+}
+
+func (h *Handler) HandleAuth(ctx context.Context, c *server.Conn, req *api.AuthRequest) (*api.AuthResponse, error) {
+	switch r := req.R.(type) {
+	case *api.AuthRequest_Auth:
+		return h.auth1(r.Auth)
+	case *api.AuthRequest_AuthGuest:
+		return h.auth1Guest(r.AuthGuest)
+	case *api.AuthRequest_Auth2:
+		return h.auth2(ctx, r.Auth2)
+	case *api.AuthRequest_CreateAccount:
+		return h.createAccount(ctx, r.CreateAccount)
+	case *api.AuthRequest_RegisterKey:
+		return h.registerKey(ctx, r.RegisterKey)
+	case *api.AuthRequest_ListKeys:
+		return h.listKeys(ctx)
+	case *api.AuthRequest_RevokeKeys:
+		return h.revokeKeys(ctx, r.RevokeKeys)
+	case *api.AuthRequest_RefreshToken:
+		return h.refreshToken(ctx, r.RefreshToken)
+	case *api.AuthRequest_ListSessions:
+		return h.listSessions(ctx)
+	case *api.AuthRequest_RevokeSessions:
+		return h.revokeSessions(ctx, r.RevokeSessions)
+	}
+	return nil, &server.RequestError{Code: api.InvalidRequest_UNKNOWN_ERROR, Reason: "not supported"}
+}
+
+// looks up a user by the name they logged in with; names that aren't valid
+// are just unknown. The user's Name is filled in either way
+func (h *Handler) user(name string) (User, error) {
+	n, err := NormalizeName(name)
+	if err != nil {
+		return User{Name: name}, ErrNoUser
+	}
+	u, err := h.Users.User(n)
+	if err == ErrNoUser {
+		u.Name = n
+	}
+	return u, err
+}
+
+// starts a session, sending back what the client needs to derive its keys
+func (h *Handler) auth1(req *api.Auth1Request) (*api.AuthResponse, error) {
+	if pk := req.GetPubKey(); pk != nil {
+		return h.auth1Key(req, pk)
+	}
+	pw := req.GetPassword()
+	if pw == nil {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "no credentials"}
+	}
+	if len(pw.ClientNonce) != NonceSize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad client nonce"}
+	}
+
+	u, err := h.user(req.UserId)
+	if err == ErrNoUser {
+		// carry on with keys nobody knows, so the handshake fails at Auth2
+		// the same way as for a wrong password
+		mac := hmac.New(sha256.New, h.secret)
+		mac.Write([]byte(u.Name))
+		u.Salt = mac.Sum(nil)[:SaltSize]
+		if u.PasswordHash, err = randomBytes(HashSize); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	snonce, err := randomBytes(NonceSize)
+	if err != nil {
+		return nil, err
+	}
+	token, err := randomBytes(TokenSize)
+	if err != nil {
+		return nil, err
+	}
+	h.Sessions.add(Session{
+		Token:      token,
+		User:       u.Name,
+		DeviceType: req.DeviceType,
+		Keys:       DeriveKeys(u.PasswordHash, pw.ClientNonce, snonce),
+	}, snonce)
+	return &api.AuthResponse{R: &api.AuthResponse_Auth{Auth: &api.Auth1Response{
+		Token: token,
+		A: &api.Auth1Response_Password_{Password: &api.Auth1Response_Password{
+			ServerNonce: snonce,
+			Salt:        u.Salt,
+		}},
+	}}}, nil
+}
+
+// finishes the handshake; the hmac on the request has already been checked
+func (h *Handler) auth2(ctx context.Context, req *api.Auth2Request) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	if sess.Authenticated {
+		return nil, authError("already authenticated")
+	}
+	if sess.DeviceKey != nil && (sess.keyLogin == nil || !ed25519.Verify(sess.DeviceKey, sess.keyLogin, req.Signature)) {
+		return nil, authError("bad signature")
+	}
+	var serverInput []byte
+	if req.EnableEncryption {
+		if len(req.ClientInput) < NonceSize {
+			return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "client input too short"}
+		}
+		var err error
+		if serverInput, err = randomBytes(NonceSize); err != nil {
+			return nil, err
+		}
+	}
+	sess, err := h.Sessions.authenticate(sess.Token, req.ClientInput, serverInput)
+	if err == ErrNoSession || err == ErrSessionExpired {
+		return nil, authError("unknown token")
+	} else if err != nil {
+		return nil, err
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_Auth2{Auth2: &api.Auth2Response{
+		EncryptionEnabled: sess.Encrypted,
+		TokenExpiry:       uint64(sess.Expiry.Unix()),
+		ServerInput:       serverInput,
+	}}}, nil
+}
+
+// passwords only ever go over encrypted sessions
+func (h *Handler) createAccount(ctx context.Context, req *api.CreateAccountRequest) (*api.AuthResponse, error) {
+	reply := func(e api.CreateAccountResponse_Error) (*api.AuthResponse, error) {
+		return &api.AuthResponse{R: &api.AuthResponse_CreateAccount{CreateAccount: &api.CreateAccountResponse{
+			Success: e == api.CreateAccountResponse_NO_ERROR,
+			Error:   e,
+		}}}, nil
+	}
+	if sess, ok := SessionFrom(ctx); !ok || !sess.Encrypted {
+		return reply(api.CreateAccountResponse_NOT_ENCRYPTED)
+	}
+	name, err := NormalizeName(req.UserId)
+	if err != nil {
+		return reply(api.CreateAccountResponse_INVALID_USER_NAME)
+	}
+	if err := CheckPassword(req.Password); err != nil {
+		return reply(api.CreateAccountResponse_INVALID_PASSWORD)
+	}
+	// save hashing the password if the name's obviously taken
+	if _, err := h.Users.User(name); err == nil {
+		return reply(api.CreateAccountResponse_USER_NAME_ALREADY_USED)
+	} else if err != ErrNoUser {
+		return nil, err
+	}
+	u, err := NewUser(name, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.Users.Add(u); err == ErrUserExists {
+		return reply(api.CreateAccountResponse_USER_NAME_ALREADY_USED)
+	} else if err != nil {
+		return nil, err
+	}
+	return reply(api.CreateAccountResponse_NO_ERROR)
+}
@@ -0,0 +1,1118 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+
+	api "github.com/cactorium/chesster-server/api"
+	codec "github.com/cactorium/chesster-server/codec"
+	server "github.com/cactorium/chesster-server/server"
+)
+
+// answers game requests with the user whose session they were made in
+type whoami struct{}
+
+func (whoami) HandleGame(ctx context.Context, c *server.Conn, req *api.GameRequest) (*api.GameResponse, error) {
+	sess, _ := SessionFrom(ctx)
+	return &api.GameResponse{Ps: []*api.PlayerResp{{PlayerId: []byte(sess.User)}}}, nil
+}
+
+// starts a server with one user, returning a connection to it
+func startServer(t *testing.T) (*Handler, *codec.Codec) {
+	users := NewMemoryUsers()
+	u, err := NewUser("alice", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users.Add(u)
+	h, err := NewHandler(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h, serve(t, h)
+}
+
+// starts a server using h, returning a connection to it
+func serve(t *testing.T, h *Handler) *codec.Codec {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &server.Server{Auth: h, Game: whoami{}, Middleware: h}
+	go s.Serve(ctx, ln)
+	nc, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		nc.Close()
+		cancel()
+	})
+	return codec.New(nc, codec.Uint32)
+}
+
+func roundTrip(t *testing.T, c *codec.Codec, m *api.Message) *api.Message {
+	if err := c.Write(m); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func randomNonce(t *testing.T) []byte {
+	n, err := randomBytes(NonceSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// the client's side of the handshake, up to sending Auth2Request
+type testClient struct {
+	token  []byte
+	keys   Keys
+	snonce []byte
+}
+
+func auth1(t *testing.T, c *codec.Codec, user, password string) (testClient, *api.Message) {
+	cnonce := randomNonce(t)
+	resp := roundTrip(t, c, &api.Message{
+		Header: &api.Header{ReqId: 1},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_Auth{Auth: &api.Auth1Request{
+			UserId:     user,
+			A:          &api.Auth1Request_Password_{Password: &api.Auth1Request_Password{ClientNonce: cnonce}},
+			DeviceType: []byte("test"),
+		}}}},
+	})
+	a1 := resp.GetAuthResp().GetAuth()
+	if a1 == nil {
+		t.Fatalf("expected Auth1Response got %v", resp)
+	}
+	hash, err := HashPassword(password, a1.GetPassword().GetSalt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testClient{
+		token:  a1.Token,
+		keys:   DeriveKeys(hash, cnonce, a1.GetPassword().GetServerNonce()),
+		snonce: resp.Header.ServerNonce,
+	}, resp
+}
+
+// a signed request
+func (tc *testClient) request(t *testing.T, id uint32, p *api.AuthRequest) *api.Message {
+	return tc.sign(t, &api.Message{
+		Header:  &api.Header{ReqId: id},
+		Payload: &api.Message_AuthReq{AuthReq: p},
+	})
+}
+
+func (tc *testClient) sign(t *testing.T, m *api.Message) *api.Message {
+	m.Header.Token = tc.token
+	m.Header.ClientNonce = randomNonce(t)
+	m.Header.ServerNonce = tc.snonce
+	if err := tc.keys.Sign(FromClient, m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// logs in as alice
+func login(t *testing.T, c *codec.Codec) testClient {
+	tc, _ := auth1(t, c, "alice", "hunter2")
+	resp := roundTrip(t, c, tc.request(t, 2, auth2Request()))
+	if resp.GetAuthResp().GetAuth2() == nil {
+		t.Fatalf("expected Auth2Response got %v", resp)
+	}
+	tc.snonce = resp.Header.ServerNonce
+	return tc
+}
+
+func auth2Request() *api.AuthRequest {
+	return &api.AuthRequest{R: &api.AuthRequest_Auth2{Auth2: &api.Auth2Request{}}}
+}
+
+func TestHandshake(t *testing.T) {
+	h, c := startServer(t)
+	tc, resp := auth1(t, c, "alice", "hunter2")
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Fatalf("Auth1Response not signed with the session keys")
+	}
+
+	before := time.Now()
+	resp = roundTrip(t, c, tc.request(t, 2, auth2Request()))
+	a2 := resp.GetAuthResp().GetAuth2()
+	if a2 == nil {
+		t.Fatalf("expected Auth2Response got %v", resp)
+	}
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("Auth2Response not signed with the session keys")
+	}
+	expiry := time.Unix(int64(a2.TokenExpiry), 0)
+	if expiry.Before(before.Add(SessionLifetime-time.Second)) || expiry.After(time.Now().Add(SessionLifetime)) {
+		t.Errorf("expected expiry in %s got %s", SessionLifetime, expiry.Sub(before))
+	}
+	if sess, err := h.Sessions.Get(tc.token); err != nil || !sess.Authenticated || sess.User != "alice" || string(sess.DeviceType) != "test" {
+		t.Errorf("session not authenticated: %v %v", sess, err)
+	}
+
+	// only once per session
+	resp = roundTrip(t, c, tc.request(t, 3, auth2Request()))
+	if resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+}
+
+func TestHandshakeFailures(t *testing.T) {
+	h, c := startServer(t)
+
+	// wrong password and unknown user fail the same way
+	for _, login := range [][2]string{{"alice", "hunter3"}, {"bob", "hunter2"}} {
+		tc, resp := auth1(t, c, login[0], login[1])
+		if tc.keys.Verify(FromServer, resp) {
+			t.Errorf("%s: Auth1Response verified with the wrong keys", login[0])
+		}
+		resp = roundTrip(t, c, tc.request(t, 2, auth2Request()))
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+			t.Errorf("%s: expected %s got %v", login[0], api.InvalidRequest_AUTH_ERROR, resp)
+		}
+	}
+
+	// the same user gets the same salt every time
+	salt := func(m *api.Message) string {
+		return string(m.GetAuthResp().GetAuth().GetPassword().GetSalt())
+	}
+	_, first := auth1(t, c, "bob", "")
+	_, second := auth1(t, c, "bob", "")
+	if len(salt(first)) != SaltSize || salt(first) != salt(second) {
+		t.Errorf("expected the same salt for bob each time")
+	}
+
+	// and after a restart, as long as the secret's kept
+	secrets := mapSecrets{}
+	if err := h.LoadSecret(secrets); err != nil {
+		t.Fatal(err)
+	}
+	_, first = auth1(t, c, "bob", "")
+	h2, err := NewHandler(h.Users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h2.LoadSecret(secrets); err != nil {
+		t.Fatal(err)
+	}
+	_, second = auth1(t, serve(t, h2), "bob", "")
+	if salt(first) != salt(second) {
+		t.Errorf("expected the same salt for bob after a restart")
+	}
+
+	tc, _ := auth1(t, c, "alice", "hunter2")
+	m := tc.request(t, 2, auth2Request())
+	m.Header.ReqId = 3
+	if resp := roundTrip(t, c, m); resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("tampered header: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+
+	now := time.Now()
+	h.Sessions.Clock = func() time.Time {
+		return now.Add(HandshakeTimeout)
+	}
+	if resp := roundTrip(t, c, tc.request(t, 4, auth2Request())); resp.GetInvalidReq().GetCode() != api.InvalidRequest_TOKEN_EXPIRED {
+		t.Errorf("late acknowledgement: expected %s got %v", api.InvalidRequest_TOKEN_EXPIRED, resp)
+	}
+}
+
+func TestMAC(t *testing.T) {
+	k := DeriveKeys([]byte("secret"), []byte("cnonce"), []byte("snonce"))
+	m := &api.Message{
+		Header:  &api.Header{Token: []byte("token"), ClientNonce: []byte("c"), ServerNonce: []byte("s")},
+		Payload: &api.Message_GameReq{GameReq: &api.GameRequest{}},
+	}
+	if err := k.Sign(FromClient, m); err != nil {
+		t.Fatal(err)
+	}
+	if !k.Verify(FromClient, m) {
+		t.Errorf("signed message didn't verify")
+	}
+	if k.Verify(FromServer, m) {
+		t.Errorf("message verified in the wrong direction")
+	}
+	m.Header.ClientNonce = []byte("d")
+	if k.Verify(FromClient, m) {
+		t.Errorf("changed nonce still verified")
+	}
+}
+
+func whoamiRequest(id uint32) *api.Message {
+	return &api.Message{
+		Header:  &api.Header{ReqId: id},
+		Payload: &api.Message_GameReq{GameReq: &api.GameRequest{}},
+	}
+}
+
+func TestVerifyHeaders(t *testing.T) {
+	_, c := startServer(t)
+
+	// before the handshake is done
+	tc, _ := auth1(t, c, "alice", "hunter2")
+	if resp := roundTrip(t, c, tc.sign(t, whoamiRequest(2))); resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("unauthenticated session: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+
+	tc = login(t, c)
+	resp := roundTrip(t, c, tc.sign(t, whoamiRequest(3)))
+	if ps := resp.GetGameResp().GetPs(); len(ps) != 1 || string(ps[0].PlayerId) != "alice" {
+		t.Errorf("expected alice got %v", resp)
+	}
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("response not signed")
+	}
+
+	cases := []struct {
+		name string
+		m    *api.Message
+	}{
+		{"no token", whoamiRequest(4)},
+		{"unsigned", &api.Message{Header: &api.Header{ReqId: 5, Token: tc.token}, Payload: whoamiRequest(5).Payload}},
+		{"tampered", func() *api.Message {
+			m := tc.sign(t, whoamiRequest(6))
+			m.Payload = &api.Message_GameReq{GameReq: &api.GameRequest{Gs: []*api.GameReq{{}}}}
+			return m
+		}()},
+		{"wrong direction", func() *api.Message {
+			m := tc.sign(t, whoamiRequest(7))
+			tc.keys.Sign(FromServer, m)
+			return m
+		}()},
+		{"unknown token", func() *api.Message {
+			m := tc.sign(t, whoamiRequest(8))
+			m.Header.Token = []byte("not a token")
+			return m
+		}()},
+	}
+	for _, cs := range cases {
+		resp := roundTrip(t, c, cs.m)
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+			t.Errorf("%s: expected %s got %v", cs.name, api.InvalidRequest_AUTH_ERROR, resp)
+		}
+		if len(resp.Header.Hmac) != 0 {
+			t.Errorf("%s: rejected request got a signed reply", cs.name)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	_, c := startServer(t)
+	tc := login(t, c)
+	first := tc.snonce
+
+	expectReplayed := func(name string, m *api.Message) {
+		resp := roundTrip(t, c, m)
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_REPLAYED {
+			t.Errorf("%s: expected %s got %v", name, api.InvalidRequest_REPLAYED, resp)
+		}
+		if !tc.keys.Verify(FromServer, resp) {
+			t.Errorf("%s: error not signed", name)
+		}
+	}
+
+	m := tc.sign(t, whoamiRequest(10))
+	if resp := roundTrip(t, c, m); resp.GetGameResp() == nil {
+		t.Fatalf("expected GameResponse got %v", resp)
+	}
+	expectReplayed("same message", m)
+	expectReplayed("same req_id", tc.sign(t, whoamiRequest(10)))
+
+	// a little out of order is fine, but not too far
+	if resp := roundTrip(t, c, tc.sign(t, whoamiRequest(8))); resp.GetGameResp() == nil {
+		t.Errorf("out of order: expected GameResponse got %v", resp)
+	}
+	resp := roundTrip(t, c, tc.sign(t, whoamiRequest(100)))
+	if resp.GetGameResp() == nil {
+		t.Fatalf("expected GameResponse got %v", resp)
+	}
+	expectReplayed("too old", tc.sign(t, whoamiRequest(30)))
+
+	// replies each have their own server nonce, and only the latest few
+	// can be echoed
+	if string(resp.Header.ServerNonce) == string(first) {
+		t.Errorf("server nonce wasn't rotated")
+	}
+	for i := uint32(0); i < serverNonceWindow; i++ {
+		tc.snonce = resp.Header.ServerNonce
+		resp = roundTrip(t, c, tc.sign(t, whoamiRequest(101+i)))
+	}
+	tc.snonce = first
+	expectReplayed("stale server nonce", tc.sign(t, whoamiRequest(200)))
+}
+
+func TestReplayWindow(t *testing.T) {
+	var w replayWindow
+	w.issue([]byte("s"))
+	cases := []struct {
+		id     uint32
+		cnonce string
+		snonce string
+		err    error
+	}{
+		{5, "a", "s", nil},
+		{5, "b", "s", ErrReplayed},
+		{6, "a", "s", ErrReplayed},
+		{6, "b", "t", ErrReplayed},
+		{4, "b", "s", nil},
+		{70, "c", "s", nil},
+		{6, "d", "s", ErrReplayed},
+		{7, "d", "s", nil},
+		{7, "e", "s", ErrReplayed},
+		// far enough ahead to clear the window; "a" can be used again
+		{1000, "a", "s", nil},
+		{999, "f", "s", nil},
+	}
+	for i, c := range cases {
+		if err := w.check(c.id, []byte(c.cnonce), []byte(c.snonce)); err != c.err {
+			t.Errorf("case %d: expected %v got %v", i, c.err, err)
+		}
+	}
+}
+
+// logs in as alice with encryption turned on
+func loginEncrypted(t *testing.T, c *codec.Codec) testClient {
+	tc, _ := auth1(t, c, "alice", "hunter2")
+	cinput := randomNonce(t)
+	resp := roundTrip(t, c, tc.request(t, 2, &api.AuthRequest{R: &api.AuthRequest_Auth2{Auth2: &api.Auth2Request{
+		EnableEncryption: true,
+		ClientInput:      cinput,
+	}}}))
+	a2 := resp.GetAuthResp().GetAuth2()
+	if !a2.GetEncryptionEnabled() || len(a2.GetServerInput()) == 0 {
+		t.Fatalf("expected encryption enabled got %v", resp)
+	}
+	tc.keys.DeriveEncryptionKeys(cinput, a2.ServerInput)
+	tc.snonce = resp.Header.ServerNonce
+	return tc
+}
+
+// an encrypted and signed request
+func (tc *testClient) seal(t *testing.T, m *api.Message) *api.Message {
+	if err := tc.keys.Encrypt(FromClient, m); err != nil {
+		t.Fatal(err)
+	}
+	return tc.sign(t, m)
+}
+
+func createAccountRequest(id uint32) *api.Message {
+	return &api.Message{
+		Header: &api.Header{ReqId: id},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_CreateAccount{CreateAccount: &api.CreateAccountRequest{
+			UserId:   "bob",
+			Password: "correct horse",
+		}}}},
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	_, c := startServer(t)
+
+	// plaintext sessions can't carry passwords, or encrypted payloads
+	tc := login(t, c)
+	resp := roundTrip(t, c, tc.sign(t, createAccountRequest(3)))
+	if e := resp.GetAuthResp().GetCreateAccount().GetError(); e != api.CreateAccountResponse_NOT_ENCRYPTED {
+		t.Errorf("plaintext CreateAccount: expected %s got %v", api.CreateAccountResponse_NOT_ENCRYPTED, resp)
+	}
+	tc.keys.DeriveEncryptionKeys(randomNonce(t), randomNonce(t))
+	resp = roundTrip(t, c, tc.seal(t, whoamiRequest(4)))
+	if resp.GetInvalidReq().GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+		t.Errorf("encrypted payload in plaintext session: expected %s got %v", api.InvalidRequest_MALFORMED_REQUEST, resp)
+	}
+
+	tc = loginEncrypted(t, c)
+	resp = roundTrip(t, c, tc.seal(t, whoamiRequest(3)))
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("response not signed")
+	}
+	if resp.GetEncPayload() == nil || !resp.Header.Encrypted {
+		t.Fatalf("expected encrypted response got %v", resp)
+	}
+	if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+		t.Fatal(err)
+	}
+	if ps := resp.GetGameResp().GetPs(); len(ps) != 1 || string(ps[0].PlayerId) != "alice" {
+		t.Errorf("expected alice got %v", resp)
+	}
+
+	resp = roundTrip(t, c, tc.seal(t, createAccountRequest(4)))
+	if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAuthResp().GetCreateAccount().GetError() == api.CreateAccountResponse_NOT_ENCRYPTED {
+		t.Errorf("encrypted CreateAccount rejected as not encrypted")
+	}
+
+	cases := []struct {
+		name string
+		m    *api.Message
+	}{
+		{"plaintext", tc.sign(t, whoamiRequest(5))},
+		{"tampered", func() *api.Message {
+			m := whoamiRequest(6)
+			tc.keys.Encrypt(FromClient, m)
+			m.GetEncPayload()[len(m.GetEncPayload())-1] ^= 1
+			return tc.sign(t, m)
+		}()},
+		{"wrong direction", func() *api.Message {
+			m := whoamiRequest(7)
+			tc.keys.Encrypt(FromServer, m)
+			return tc.sign(t, m)
+		}()},
+	}
+	for _, cs := range cases {
+		resp := roundTrip(t, c, cs.m)
+		if !tc.keys.Verify(FromServer, resp) {
+			t.Errorf("%s: error not signed", cs.name)
+		}
+		if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+			t.Fatalf("%s: %v", cs.name, err)
+		}
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+			t.Errorf("%s: expected %s got %v", cs.name, api.InvalidRequest_MALFORMED_REQUEST, resp)
+		}
+	}
+}
+
+// starts a guest session, up to sending Auth2Request
+func authGuest(t *testing.T, c *codec.Codec) (testClient, *api.Message) {
+	priv, err := randomBytes(curve25519.ScalarSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gnonce := randomNonce(t)
+	resp := roundTrip(t, c, &api.Message{
+		Header: &api.Header{ReqId: 1},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_AuthGuest{AuthGuest: &api.Auth1GuestRequest{
+			GuestPub:   pub,
+			GuestNonce: gnonce,
+		}}}},
+	})
+	g := resp.GetAuthResp().GetAuthGuest()
+	if g == nil {
+		t.Fatalf("expected Auth1GuestResponse got %v", resp)
+	}
+	secret, err := curve25519.X25519(priv, g.ServerPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testClient{
+		token:  g.SessionToken,
+		keys:   DeriveKeys(secret, gnonce, g.ServerNonce),
+		snonce: resp.Header.ServerNonce,
+	}, resp
+}
+
+func TestGuest(t *testing.T) {
+	h, c := startServer(t)
+	tc, resp := authGuest(t, c)
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("Auth1GuestResponse not signed with the session keys")
+	}
+	resp = roundTrip(t, c, tc.request(t, 2, auth2Request()))
+	if resp.GetAuthResp().GetAuth2() == nil {
+		t.Fatalf("expected Auth2Response got %v", resp)
+	}
+	tc.snonce = resp.Header.ServerNonce
+	sess, err := h.Sessions.Get(tc.token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sess.Guest || !sess.Authenticated || sess.User != "" {
+		t.Errorf("expected authenticated guest session got %+v", sess)
+	}
+
+	spectate := whoamiRequest(3)
+	spectate.GetGameReq().Gs = []*api.GameReq{{Actions: []*api.GameAction{
+		{Actions: &api.GameAction_Spectate{Spectate: &api.Spectate{}}},
+		{Actions: &api.GameAction_Board{Board: &api.GetBoard{}}},
+	}}}
+	if resp := roundTrip(t, c, tc.sign(t, spectate)); resp.GetGameResp() == nil {
+		t.Errorf("spectating: expected GameResponse got %v", resp)
+	}
+	resp = roundTrip(t, c, tc.sign(t, createAccountRequest(4)))
+	if resp.GetAuthResp().GetCreateAccount() == nil {
+		t.Errorf("CreateAccount: expected CreateAccountResponse got %v", resp)
+	}
+
+	play := whoamiRequest(5)
+	play.GetGameReq().Gs = []*api.GameReq{{Actions: []*api.GameAction{
+		{Actions: &api.GameAction_PlayMove{PlayMove: &api.PlayMove{}}},
+	}}}
+	start := whoamiRequest(6)
+	start.GetGameReq().Ps = []*api.PlayerReq{{Actions: []*api.PlayerAction{
+		{Actions: &api.PlayerAction_StartGame{StartGame: &api.StartGame{}}},
+	}}}
+	for name, m := range map[string]*api.Message{
+		"playing":       play,
+		"starting game": start,
+		"listing keys":  tc.request(t, 7, &api.AuthRequest{R: &api.AuthRequest_ListKeys{ListKeys: &api.ListKeys{}}}),
+	} {
+		if m.Header.Hmac == nil {
+			m = tc.sign(t, m)
+		}
+		resp := roundTrip(t, c, m)
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_NOT_ALLOWED {
+			t.Errorf("%s: expected %s got %v", name, api.InvalidRequest_NOT_ALLOWED, resp)
+		}
+		if !tc.keys.Verify(FromServer, resp) {
+			t.Errorf("%s: error not signed", name)
+		}
+	}
+}
+
+func TestGuestBadKey(t *testing.T) {
+	_, c := startServer(t)
+	for name, pub := range map[string][]byte{
+		"short":     []byte("too short"),
+		"low order": make([]byte, curve25519.PointSize),
+	} {
+		resp := roundTrip(t, c, &api.Message{
+			Header: &api.Header{ReqId: 1},
+			Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_AuthGuest{AuthGuest: &api.Auth1GuestRequest{
+				GuestPub:   pub,
+				GuestNonce: randomNonce(t),
+			}}}},
+		})
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+			t.Errorf("%s: expected %s got %v", name, api.InvalidRequest_MALFORMED_REQUEST, resp)
+		}
+	}
+}
+
+func newDeviceKey(t *testing.T) (priv, pub []byte) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, pub
+}
+
+// logs in with a device key, signing the handshake with priv, returning the
+// Auth2Response
+func loginKey(t *testing.T, c *codec.Codec, user string, priv, pub []byte) (testClient, *api.Message) {
+	dhPriv, err := randomBytes(curve25519.ScalarSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dhPub, err := curve25519.X25519(dhPriv, curve25519.Basepoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cnonce := randomNonce(t)
+	resp := roundTrip(t, c, &api.Message{
+		Header: &api.Header{ReqId: 1},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_Auth{Auth: &api.Auth1Request{
+			UserId:     user,
+			A:          &api.Auth1Request_PubKey_{PubKey: &api.Auth1Request_PubKey{EccPub: pub, DhPub: dhPub, ClientNonce: cnonce}},
+			DeviceType: []byte("phone"),
+		}}}},
+	})
+	a1 := resp.GetAuthResp().GetAuth()
+	if a1.GetPubKey() == nil {
+		t.Fatalf("expected Auth1Response with a key got %v", resp)
+	}
+	spub, snonce := a1.GetPubKey().EccPub, a1.GetPubKey().ServerNonce
+	secret, err := curve25519.X25519(dhPriv, spub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := testClient{
+		token:  a1.Token,
+		keys:   DeriveKeys(secret, cnonce, snonce),
+		snonce: resp.Header.ServerNonce,
+	}
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("Auth1Response not signed with the session keys")
+	}
+	sig := ed25519.Sign(ed25519.PrivateKey(priv), KeyLoginMessage(user, a1.Token, pub, dhPub, cnonce, spub, snonce))
+	a2 := roundTrip(t, c, tc.request(t, 2, &api.AuthRequest{R: &api.AuthRequest_Auth2{Auth2: &api.Auth2Request{Signature: sig}}}))
+	tc.snonce = a2.Header.ServerNonce
+	return tc, a2
+}
+
+// registers pub in tc's session, signed with priv
+func registerKeyRequest(tc testClient, priv, pub []byte) *api.AuthRequest {
+	var sig []byte
+	if len(priv) == ed25519.PrivateKeySize {
+		sig = ed25519.Sign(ed25519.PrivateKey(priv), RegisterKeyMessage(tc.token, pub))
+	}
+	return &api.AuthRequest{R: &api.AuthRequest_RegisterKey{RegisterKey: &api.RegisterKeyRequest{EccPub: pub, Signature: sig}}}
+}
+
+func TestDeviceKeys(t *testing.T) {
+	_, c := startServer(t)
+	tc := login(t, c)
+	priv, pub := newDeviceKey(t)
+	otherPriv, otherPub := newDeviceKey(t)
+	otherSession := tc
+	otherSession.token = []byte("another session")
+
+	for i, cs := range []struct {
+		req *api.AuthRequest
+		err api.RegisterKeyResponse_Error
+	}{
+		{registerKeyRequest(tc, otherPriv, pub), api.RegisterKeyResponse_BAD_SIGNATURE},
+		{registerKeyRequest(otherSession, priv, pub), api.RegisterKeyResponse_BAD_SIGNATURE},
+		{registerKeyRequest(tc, nil, pub), api.RegisterKeyResponse_BAD_SIGNATURE},
+		{registerKeyRequest(tc, priv, pub), api.RegisterKeyResponse_NO_ERROR},
+		{registerKeyRequest(tc, priv, pub), api.RegisterKeyResponse_KEY_ALREADY_REGISTERED},
+		{registerKeyRequest(tc, nil, []byte("short")), api.RegisterKeyResponse_BAD_KEY},
+		{registerKeyRequest(tc, nil, make([]byte, ed25519.PublicKeySize+1)), api.RegisterKeyResponse_BAD_KEY},
+	} {
+		resp := roundTrip(t, c, tc.request(t, uint32(3+i), cs.req))
+		tc.snonce = resp.Header.ServerNonce
+		r := resp.GetAuthResp().GetRegisterKey()
+		if r == nil || r.Error != cs.err || r.Success != (cs.err == api.RegisterKeyResponse_NO_ERROR) {
+			t.Errorf("case %d: expected %s got %v", i, cs.err, resp)
+		}
+	}
+
+	resp := roundTrip(t, c, tc.request(t, 10, &api.AuthRequest{R: &api.AuthRequest_ListKeys{ListKeys: &api.ListKeys{}}}))
+	l := resp.GetAuthResp().GetListKeys()
+	if len(l.GetKeys()) != 1 || string(l.Keys[0]) != string(pub) || string(l.DeviceType[0]) != "test" {
+		t.Errorf("expected the registered key got %v", resp)
+	}
+
+	kc, resp := loginKey(t, c, "alice", priv, pub)
+	if resp.GetAuthResp().GetAuth2() == nil {
+		t.Fatalf("expected Auth2Response got %v", resp)
+	}
+	resp = roundTrip(t, c, kc.sign(t, whoamiRequest(3)))
+	if ps := resp.GetGameResp().GetPs(); len(ps) != 1 || string(ps[0].PlayerId) != "alice" {
+		t.Errorf("expected alice got %v", resp)
+	}
+
+	// the key only works for who registered it, and only with its private key
+	for name, k := range map[string][2][]byte{
+		"wrong user":        {priv, pub},
+		"unregistered key":  {otherPriv, otherPub},
+		"wrong private key": {otherPriv, pub},
+	} {
+		user := "alice"
+		if name == "wrong user" {
+			user = "bob"
+		}
+		_, resp := loginKey(t, c, user, k[0], k[1])
+		if resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+			t.Errorf("%s: expected %s got %v", name, api.InvalidRequest_AUTH_ERROR, resp)
+		}
+	}
+
+	// a name that isn't one isn't looked up as is
+	resp = roundTrip(t, c, &api.Message{
+		Header: &api.Header{ReqId: 1},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_Auth{Auth: &api.Auth1Request{
+			UserId: "Alice!",
+			A:      &api.Auth1Request_PubKey_{PubKey: &api.Auth1Request_PubKey{EccPub: pub, DhPub: pub, ClientNonce: randomNonce(t)}},
+		}}}},
+	})
+	if resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("invalid name: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+
+	resp = roundTrip(t, c, tc.request(t, 11, &api.AuthRequest{R: &api.AuthRequest_RevokeKeys{RevokeKeys: &api.RevokeKeysRequest{
+		Keys: [][]byte{pub, otherPub},
+	}}}))
+	r := resp.GetAuthResp().GetRevokeKeys()
+	if len(r.GetSuccess()) != 2 || !r.Success[0] || r.Success[1] {
+		t.Errorf("expected only the registered key revoked got %v", resp)
+	}
+	// which ends its session, and it can't be logged in with again
+	resp = roundTrip(t, c, kc.sign(t, whoamiRequest(4)))
+	if resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("revoked key's session: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+	if _, resp := loginKey(t, c, "alice", priv, pub); resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("revoked key: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+}
+
+func TestRevokeOwnKey(t *testing.T) {
+	_, c := startServer(t)
+	tc := login(t, c)
+	priv, pub := newDeviceKey(t)
+	roundTrip(t, c, tc.request(t, 3, registerKeyRequest(tc, priv, pub)))
+
+	kc, _ := loginKey(t, c, "alice", priv, pub)
+	resp := roundTrip(t, c, kc.request(t, 3, &api.AuthRequest{R: &api.AuthRequest_RevokeKeys{RevokeKeys: &api.RevokeKeysRequest{
+		Keys: [][]byte{pub},
+	}}}))
+	if r := resp.GetAuthResp().GetRevokeKeys(); len(r.GetSuccess()) != 1 || !r.Success[0] {
+		t.Errorf("expected key revoked got %v", resp)
+	}
+	if !kc.keys.Verify(FromServer, resp) {
+		t.Errorf("reply to the session's last request not signed")
+	}
+}
+
+// refreshes tc's session, returning a client for the new one
+func (tc *testClient) refresh(t *testing.T, c *codec.Codec, id uint32, encrypted bool) testClient {
+	cinput := randomNonce(t)
+	m := &api.Message{
+		Header: &api.Header{ReqId: id},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_RefreshToken{RefreshToken: &api.RefreshTokenRequest{
+			ClientInput: cinput,
+		}}}},
+	}
+	if encrypted {
+		m = tc.seal(t, m)
+	} else {
+		m = tc.sign(t, m)
+	}
+	resp := roundTrip(t, c, m)
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("refresh reply not signed")
+	}
+	if encrypted {
+		if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := resp.GetAuthResp().GetRefreshToken()
+	if r == nil {
+		t.Fatalf("expected RefreshTokenResponse got %v", resp)
+	}
+	tc.snonce = resp.Header.ServerNonce
+	return testClient{
+		token:  r.Token,
+		keys:   tc.keys.Refresh(cinput, r.ServerInput),
+		snonce: r.ServerInput,
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	h, c := startServer(t)
+	tc := login(t, c)
+	nc := tc.refresh(t, c, 3, false)
+	if string(nc.token) == string(tc.token) {
+		t.Fatalf("refresh gave the same token")
+	}
+	sess, err := h.Sessions.Get(nc.token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.User != "alice" || !sess.Authenticated || sess.Encrypted {
+		t.Errorf("expected alice's unencrypted session got %+v", sess)
+	}
+
+	// the old token works until the new one is used
+	if resp := roundTrip(t, c, tc.sign(t, whoamiRequest(4))); resp.GetGameResp() == nil {
+		t.Errorf("old token before first use: expected GameResponse got %v", resp)
+	}
+	resp := roundTrip(t, c, nc.sign(t, whoamiRequest(1)))
+	if ps := resp.GetGameResp().GetPs(); len(ps) != 1 || string(ps[0].PlayerId) != "alice" {
+		t.Errorf("new token: expected alice got %v", resp)
+	}
+	if !nc.keys.Verify(FromServer, resp) {
+		t.Errorf("new token: response not signed with the new keys")
+	}
+	if resp := roundTrip(t, c, tc.sign(t, whoamiRequest(5))); resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("old token after first use: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+
+	// encrypted sessions stay encrypted
+	tc = loginEncrypted(t, c)
+	nc = tc.refresh(t, c, 3, true)
+	resp = roundTrip(t, c, nc.seal(t, whoamiRequest(1)))
+	if err := nc.keys.Decrypt(FromServer, resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetGameResp() == nil {
+		t.Errorf("encrypted refresh: expected GameResponse got %v", resp)
+	}
+}
+
+func TestSessionManagement(t *testing.T) {
+	h, c := startServer(t)
+	bob, err := NewUser("bob", "swordfish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Users.(*MemoryUsers).Add(bob)
+
+	first := login(t, c)
+	second := login(t, c)
+	bc, _ := auth1(t, c, "bob", "swordfish")
+	roundTrip(t, c, bc.request(t, 2, auth2Request()))
+	// not finished, so not listed
+	auth1(t, c, "alice", "hunter2")
+
+	resp := roundTrip(t, c, first.request(t, 3, &api.AuthRequest{R: &api.AuthRequest_ListSessions{ListSessions: &api.ListSessions{}}}))
+	l := resp.GetAuthResp().GetListSessions()
+	if len(l.GetSessions()) != 2 || len(l.DeviceType) != 2 || len(l.Expiration) != 2 {
+		t.Fatalf("expected alice's two sessions got %v", resp)
+	}
+	for i, tok := range l.Sessions {
+		sess, err := h.Sessions.Get(tok)
+		if err != nil || sess.User != "alice" || string(l.DeviceType[i]) != "test" || l.Expiration[i] != uint64(sess.Expiry.Unix()) {
+			t.Errorf("session %d: got %x %q %d", i, tok, l.DeviceType[i], l.Expiration[i])
+		}
+	}
+
+	resp = roundTrip(t, c, first.request(t, 4, &api.AuthRequest{R: &api.AuthRequest_RevokeSessions{RevokeSessions: &api.RevokeSessionsRequest{
+		Sessions: [][]byte{second.token, bc.token, []byte("not a token")},
+	}}}))
+	r := resp.GetAuthResp().GetRevokeSessions()
+	if len(r.GetSuccess()) != 3 || !r.Success[0] || r.Success[1] || r.Success[2] {
+		t.Errorf("expected only alice's session revoked got %v", resp)
+	}
+	if resp := roundTrip(t, c, second.sign(t, whoamiRequest(3))); resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("revoked session: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+	if _, err := h.Sessions.Get(bc.token); err != nil {
+		t.Errorf("bob's session was revoked")
+	}
+
+	// revoking the session the request is made in still gets a signed reply
+	resp = roundTrip(t, c, first.request(t, 5, &api.AuthRequest{R: &api.AuthRequest_RevokeSessions{RevokeSessions: &api.RevokeSessionsRequest{
+		Sessions: [][]byte{first.token},
+	}}}))
+	if r := resp.GetAuthResp().GetRevokeSessions(); len(r.GetSuccess()) != 1 || !r.Success[0] {
+		t.Errorf("expected own session revoked got %v", resp)
+	}
+	if !first.keys.Verify(FromServer, resp) {
+		t.Errorf("reply to the session's last request not signed")
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	h, c := startServer(t)
+	tc := login(t, c)
+	now := time.Now()
+	h.Sessions.Clock = func() time.Time {
+		return now.Add(SessionLifetime + time.Second)
+	}
+	for i := uint32(0); i < 2; i++ {
+		if resp := roundTrip(t, c, tc.sign(t, whoamiRequest(3+i))); resp.GetInvalidReq().GetCode() != api.InvalidRequest_TOKEN_EXPIRED {
+			t.Errorf("request %d: expected %s got %v", i, api.InvalidRequest_TOKEN_EXPIRED, resp)
+		}
+	}
+	if ss := h.Sessions.List("alice"); len(ss) != 0 {
+		t.Errorf("expired session listed: %+v", ss)
+	}
+
+	// forgotten eventually
+	h.Sessions.Clock = func() time.Time {
+		return now.Add(SessionLifetime + expiredGrace + time.Minute)
+	}
+	if err := h.Sessions.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Sessions.Get(tc.token); err != ErrNoSession {
+		t.Errorf("expected %v got %v", ErrNoSession, err)
+	}
+}
+
+func TestHandshakesPruned(t *testing.T) {
+	s := NewSessions()
+	now := time.Now()
+	s.Clock = func() time.Time { return now }
+	token := func(i int) []byte {
+		return []byte(fmt.Sprint("t", i))
+	}
+	for i := 0; i < MaxHandshakes+10; i++ {
+		s.add(Session{Token: token(i), User: "alice"}, nil)
+	}
+	if len(s.sessions) != MaxHandshakes {
+		t.Errorf("expected %d handshakes kept got %d", MaxHandshakes, len(s.sessions))
+	}
+	if _, err := s.Get(token(9)); err != ErrNoSession {
+		t.Errorf("oldest handshake: expected %v got %v", ErrNoSession, err)
+	}
+	if _, err := s.authenticate(token(10), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// unfinished handshakes are forgotten as soon as they time out, without
+	// waiting out the grace period
+	s.Clock = func() time.Time { return now.Add(HandshakeTimeout) }
+	if err := s.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.sessions) != 1 || len(s.handshakes) != 0 {
+		t.Errorf("expected only the authenticated session left got %d, %d handshakes", len(s.sessions), len(s.handshakes))
+	}
+	if _, err := s.Get(token(10)); err != nil {
+		t.Errorf("authenticated session: %v", err)
+	}
+}
+
+func TestCreateAccount(t *testing.T) {
+	h, c := startServer(t)
+	tc := loginEncrypted(t, c)
+	create := func(id uint32, name, password string) api.CreateAccountResponse_Error {
+		m := createAccountRequest(id)
+		m.GetAuthReq().GetCreateAccount().UserId = name
+		m.GetAuthReq().GetCreateAccount().Password = password
+		resp := roundTrip(t, c, tc.seal(t, m))
+		if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+			t.Fatal(err)
+		}
+		r := resp.GetAuthResp().GetCreateAccount()
+		if r == nil {
+			t.Fatalf("expected CreateAccountResponse got %v", resp)
+		}
+		if r.Success != (r.Error == api.CreateAccountResponse_NO_ERROR) {
+			t.Errorf("%q: success %v with error %s", name, r.Success, r.Error)
+		}
+		return r.Error
+	}
+
+	cases := []struct {
+		name     string
+		password string
+		err      api.CreateAccountResponse_Error
+	}{
+		{" Bob", "correct horse", api.CreateAccountResponse_NO_ERROR},
+		{"bob", "battery staple", api.CreateAccountResponse_USER_NAME_ALREADY_USED},
+		{"ALICE", "battery staple", api.CreateAccountResponse_USER_NAME_ALREADY_USED},
+		{"b", "battery staple", api.CreateAccountResponse_INVALID_USER_NAME},
+		{"bob smith", "battery staple", api.CreateAccountResponse_INVALID_USER_NAME},
+		{"carol", "short", api.CreateAccountResponse_INVALID_PASSWORD},
+	}
+	for i, cs := range cases {
+		if err := create(uint32(3+i), cs.name, cs.password); err != cs.err {
+			t.Errorf("%q: expected %s got %s", cs.name, cs.err, err)
+		}
+	}
+
+	u, err := h.Users.User("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Salt) != SaltSize || len(u.PasswordHash) != HashSize {
+		t.Errorf("bad salt or hash: %+v", u)
+	}
+	// names are case insensitive when logging in too, and the salt sent
+	// back is the stored one
+	bc, resp := auth1(t, c, "BOB", "correct horse")
+	if string(resp.GetAuthResp().GetAuth().GetPassword().GetSalt()) != string(u.Salt) {
+		t.Errorf("expected the stored salt got %v", resp)
+	}
+	resp = roundTrip(t, c, bc.request(t, 2, auth2Request()))
+	if resp.GetAuthResp().GetAuth2() == nil {
+		t.Fatalf("expected Auth2Response got %v", resp)
+	}
+	bc.snonce = resp.Header.ServerNonce
+	resp = roundTrip(t, c, bc.sign(t, whoamiRequest(3)))
+	if ps := resp.GetGameResp().GetPs(); len(ps) != 1 || string(ps[0].PlayerId) != "bob" {
+		t.Errorf("expected bob got %v", resp)
+	}
+}
+
+// a SecretStore in a map
+type mapSecrets map[string][]byte
+
+func (m mapSecrets) Secret(name string, s []byte) ([]byte, error) {
+	if kept, ok := m[name]; ok {
+		return kept, nil
+	}
+	m[name] = s
+	return s, nil
+}
+
+// a SessionStore in a map
+type mapSessions map[string]SavedSession
+
+func (m mapSessions) SaveSession(s SavedSession) error {
+	m[string(s.Token)] = s
+	return nil
+}
+
+func (m mapSessions) DeleteSession(token []byte) error {
+	delete(m, string(token))
+	return nil
+}
+
+func (m mapSessions) LoadSessions() ([]SavedSession, error) {
+	var ss []SavedSession
+	for _, s := range m {
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+func TestSessionsRestart(t *testing.T) {
+	h, c := startServer(t)
+	store := mapSessions{}
+	h.Sessions.Store = store
+	// not saved until the handshake's done
+	auth1(t, c, "alice", "hunter2")
+	if len(store) != 0 {
+		t.Errorf("unauthenticated session saved")
+	}
+	tc := loginEncrypted(t, c)
+	saved := string(store[string(tc.token)].State)
+	old := tc.seal(t, whoamiRequest(3))
+	resp := roundTrip(t, c, old)
+	tc.snonce = resp.Header.ServerNonce
+	// requests are only checked against the window kept in memory
+	if string(store[string(tc.token)].State) != saved {
+		t.Errorf("session saved again for a request")
+	}
+	revoked := login(t, c)
+	roundTrip(t, c, revoked.request(t, 3, &api.AuthRequest{R: &api.AuthRequest_RevokeSessions{RevokeSessions: &api.RevokeSessionsRequest{
+		Sessions: [][]byte{revoked.token},
+	}}}))
+
+	h2, err := NewHandler(h.Users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2.Sessions.Store = store
+	if err := h2.Sessions.Load(); err != nil {
+		t.Fatal(err)
+	}
+	c = serve(t, h2)
+
+	// the server nonces from before the restart are gone, so the first
+	// request has to be sent again with the one in its error
+	resp = roundTrip(t, c, tc.seal(t, whoamiRequest(4)))
+	if !tc.keys.Verify(FromServer, resp) {
+		t.Errorf("response not signed")
+	}
+	if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetInvalidReq().GetCode() != api.InvalidRequest_REPLAYED {
+		t.Errorf("first request after the restart: expected %s got %v", api.InvalidRequest_REPLAYED, resp)
+	}
+	tc.snonce = resp.Header.ServerNonce
+	resp = roundTrip(t, c, tc.seal(t, whoamiRequest(5)))
+	if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+		t.Fatal(err)
+	}
+	if ps := resp.GetGameResp().GetPs(); len(ps) != 1 || string(ps[0].PlayerId) != "alice" {
+		t.Errorf("expected alice got %v", resp)
+	}
+	resp = roundTrip(t, c, old)
+	if err := tc.keys.Decrypt(FromServer, resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetInvalidReq().GetCode() != api.InvalidRequest_REPLAYED {
+		t.Errorf("request from before the restart: expected %s got %v", api.InvalidRequest_REPLAYED, resp)
+	}
+	if resp := roundTrip(t, c, revoked.sign(t, whoamiRequest(4))); resp.GetInvalidReq().GetCode() != api.InvalidRequest_AUTH_ERROR {
+		t.Errorf("revoked session: expected %s got %v", api.InvalidRequest_AUTH_ERROR, resp)
+	}
+}
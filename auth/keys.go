@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"io"
+
+	proto "github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/hkdf"
+
+	api "github.com/cactorium/chesster-server/api"
+)
+
+// which side sent a message; each side signs with its own keys
+type Direction int
+
+const (
+	FromClient Direction = iota
+	FromServer
+)
+
+const keySize = 32
+
+// the secret keys for one session
+type Keys struct {
+	// Key1 and Key2 from the README, for each direction
+	ClientMAC [2][]byte
+	ServerMAC [2][]byte
+	// AES-256-GCM keys for each direction; nil unless encryption is on
+	ClientEnc []byte
+	ServerEnc []byte
+
+	// what the encryption keys are derived from
+	encSecret []byte
+	// what the keys for the session's replacement are derived from, when
+	// refreshing its token
+	refreshSecret []byte
+}
+
+// derives a session's keys from the secret both sides share, which is the
+// salted password hash for password logins, and both handshake nonces
+func DeriveKeys(secret, clientNonce, serverNonce []byte) Keys {
+	salt := append(append([]byte{}, clientNonce...), serverNonce...)
+	r := hkdf.New(sha256.New, secret, salt, []byte("chesster session keys"))
+	next := func() []byte {
+		k := make([]byte, keySize)
+		// can't fail for this little output
+		io.ReadFull(r, k)
+		return k
+	}
+	var k Keys
+	k.ClientMAC[0], k.ClientMAC[1] = next(), next()
+	k.ServerMAC[0], k.ServerMAC[1] = next(), next()
+	k.encSecret = next()
+	k.refreshSecret = next()
+	return k
+}
+
+// derives the encryption keys once both sides have sent their random inputs
+// in the handshake acknowledgement
+func (k *Keys) DeriveEncryptionKeys(clientInput, serverInput []byte) {
+	salt := append(append([]byte{}, clientInput...), serverInput...)
+	r := hkdf.New(sha256.New, k.encSecret, salt, []byte("chesster encryption keys"))
+	k.ClientEnc = make([]byte, keySize)
+	k.ServerEnc = make([]byte, keySize)
+	io.ReadFull(r, k.ClientEnc)
+	io.ReadFull(r, k.ServerEnc)
+}
+
+// the keys for a session replacing this one, from both sides' random inputs
+// in the refresh request; it's encrypted if this one is
+func (k *Keys) Refresh(clientInput, serverInput []byte) Keys {
+	n := DeriveKeys(k.refreshSecret, clientInput, serverInput)
+	if k.ClientEnc != nil {
+		n.DeriveEncryptionKeys(clientInput, serverInput)
+	}
+	return n
+}
+
+// Hash((key1 xor opad) + Hash((key2 xor ipad) + snonce + message + cnonce + token))
+// as in the README; HMAC except with a different key on the inside
+func mac(key1, key2, snonce, msg, cnonce, token []byte) []byte {
+	var opad, ipad [sha256.BlockSize]byte
+	copy(opad[:], key1)
+	copy(ipad[:], key2)
+	for i := range opad {
+		opad[i] ^= 0x5c
+		ipad[i] ^= 0x36
+	}
+	inner := sha256.New()
+	inner.Write(ipad[:])
+	inner.Write(snonce)
+	inner.Write(msg)
+	inner.Write(cnonce)
+	inner.Write(token)
+	outer := sha256.New()
+	outer.Write(opad[:])
+	outer.Write(inner.Sum(nil))
+	return outer.Sum(nil)
+}
+
+// the MAC of m as sent in direction d; the message part is m marshalled
+// with its hmac left out
+func (k *Keys) MAC(d Direction, m *api.Message) ([]byte, error) {
+	keys := k.ClientMAC
+	if d == FromServer {
+		keys = k.ServerMAC
+	}
+	h := m.GetHeader()
+	unsigned := *m
+	if h != nil {
+		uh := *h
+		uh.Hmac = nil
+		unsigned.Header = &uh
+	}
+	msg, err := proto.Marshal(&unsigned)
+	if err != nil {
+		return nil, err
+	}
+	return mac(keys[0], keys[1], h.GetServerNonce(), msg, h.GetClientNonce(), h.GetToken()), nil
+}
+
+// fills in the hmac in m's header
+func (k *Keys) Sign(d Direction, m *api.Message) error {
+	if m.Header == nil {
+		m.Header = &api.Header{}
+	}
+	sum, err := k.MAC(d, m)
+	if err != nil {
+		return err
+	}
+	m.Header.Hmac = sum
+	return nil
+}
+
+func (k *Keys) Verify(d Direction, m *api.Message) bool {
+	sum, err := k.MAC(d, m)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sum, m.GetHeader().GetHmac())
+}
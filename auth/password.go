@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/rand"
+	"errors"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters; changing them breaks every stored hash
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	SaltSize = 16
+	HashSize = 32
+
+	MinPasswordLength = 8
+	// scrypt doesn't care, but there's no reason to take megabytes of
+	// password either
+	MaxPasswordLength = 1024
+)
+
+var ErrBadPassword = errors.New("password too short or too long")
+
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// the rules for new accounts' passwords: at least MinPasswordLength
+// characters, and at most MaxPasswordLength bytes
+func CheckPassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrBadPassword
+	}
+	return nil
+}
+
+// the salted password hash stored for each user. The client works it out
+// from the salt in Auth1Response, and both sides derive the session keys
+// from it, so the password itself never has to be sent
+func HashPassword(password string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, HashSize)
+}
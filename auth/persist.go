@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+)
+
+// a session as it's kept in a SessionStore; State is everything else about
+// it, which only this package needs to understand
+type SavedSession struct {
+	Token  []byte
+	User   string
+	Expiry time.Time
+	State  []byte
+}
+
+// where sessions are kept so they survive a restart
+type SessionStore interface {
+	// adds or replaces the session with s.Token
+	SaveSession(s SavedSession) error
+	// fine if there's no such session
+	DeleteSession(token []byte) error
+	LoadSessions() ([]SavedSession, error)
+}
+
+// what goes in SavedSession.State. The replay window isn't saved, since it
+// changes with every request; a session read back after a restart has no
+// server nonces, so nothing from before it is accepted, and the REPLAYED
+// error for its first request carries a nonce to send it again with
+type savedState struct {
+	DeviceType    []byte
+	DeviceKey     []byte
+	Guest         bool
+	Authenticated bool
+	Encrypted     bool
+
+	ClientMAC     [2][]byte
+	ServerMAC     [2][]byte
+	ClientEnc     []byte
+	ServerEnc     []byte
+	EncSecret     []byte
+	RefreshSecret []byte
+
+	Replaces []byte
+}
+
+func (e *sessionEntry) saved() (SavedSession, error) {
+	st := savedState{
+		DeviceType:    e.DeviceType,
+		DeviceKey:     e.DeviceKey,
+		Guest:         e.Guest,
+		Authenticated: e.Authenticated,
+		Encrypted:     e.Encrypted,
+		ClientMAC:     e.Keys.ClientMAC,
+		ServerMAC:     e.Keys.ServerMAC,
+		ClientEnc:     e.Keys.ClientEnc,
+		ServerEnc:     e.Keys.ServerEnc,
+		EncSecret:     e.Keys.encSecret,
+		RefreshSecret: e.Keys.refreshSecret,
+		Replaces:      e.replaces,
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&st); err != nil {
+		return SavedSession{}, err
+	}
+	return SavedSession{Token: e.Token, User: e.User, Expiry: e.Expiry, State: buf.Bytes()}, nil
+}
+
+func loadEntry(s SavedSession) (*sessionEntry, error) {
+	var st savedState
+	if err := gob.NewDecoder(bytes.NewReader(s.State)).Decode(&st); err != nil {
+		return nil, err
+	}
+	return &sessionEntry{
+		Session: Session{
+			Token:      s.Token,
+			User:       s.User,
+			DeviceType: st.DeviceType,
+			Keys: Keys{
+				ClientMAC:     st.ClientMAC,
+				ServerMAC:     st.ServerMAC,
+				ClientEnc:     st.ClientEnc,
+				ServerEnc:     st.ServerEnc,
+				encSecret:     st.EncSecret,
+				refreshSecret: st.RefreshSecret,
+			},
+			Expiry:        s.Expiry,
+			Authenticated: st.Authenticated,
+			Encrypted:     st.Encrypted,
+			Guest:         st.Guest,
+			DeviceKey:     st.DeviceKey,
+		},
+		replaces: st.Replaces,
+	}, nil
+}
+
+// writes a session through to the Store. That's only done when it's
+// authenticated or refreshed, or starts replacing the token it was refreshed
+// from; sessions still in the handshake aren't worth keeping, so they're left
+// out
+func (s *Sessions) save(e *sessionEntry) error {
+	if s.Store == nil || !e.Authenticated {
+		return nil
+	}
+	saved, err := e.saved()
+	if err != nil {
+		return err
+	}
+	return s.Store.SaveSession(saved)
+}
+
+func (s *Sessions) delete(token []byte) error {
+	delete(s.sessions, string(token))
+	if s.Store == nil {
+		return nil
+	}
+	return s.Store.DeleteSession(token)
+}
+
+// reads back the sessions saved in the Store, after a restart
+func (s *Sessions) Load() error {
+	if s.Store == nil {
+		return nil
+	}
+	saved, err := s.Store.LoadSessions()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ss := range saved {
+		e, err := loadEntry(ss)
+		if err != nil {
+			return err
+		}
+		s.sessions[string(e.Token)] = e
+	}
+	return nil
+}
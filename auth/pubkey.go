@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+
+	"golang.org/x/crypto/curve25519"
+
+	api "github.com/cactorium/chesster-server/api"
+	server "github.com/cactorium/chesster-server/server"
+)
+
+// starts a session for a device logging in with its key. The device and the
+// server each make an X25519 key for just this login, and the session keys
+// are derived from the exchange of the two like a guest's. That alone doesn't
+// say who the device is, so its Auth2Request also has to carry its device
+// key's signature of the handshake, from KeyLoginMessage
+func (h *Handler) auth1Key(req *api.Auth1Request, pk *api.Auth1Request_PubKey) (*api.AuthResponse, error) {
+	if len(pk.EccPub) != ed25519.PublicKeySize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad device key"}
+	}
+	if len(pk.DhPub) != curve25519.PointSize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad exchange key"}
+	}
+	if len(pk.ClientNonce) != NonceSize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad client nonce"}
+	}
+	user, err := NormalizeName(req.UserId)
+	if err != nil {
+		return nil, authError("no such user")
+	}
+
+	priv, err := randomBytes(curve25519.ScalarSize)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	// fails for low order points, which would give a secret anyone knows
+	secret, err := curve25519.X25519(priv, pk.DhPub)
+	if err != nil {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "bad exchange key"}
+	}
+	// like an unknown user with a password, an unknown key carries on so it
+	// fails at Auth2 the same way as a bad signature
+	known := true
+	if _, err := h.DeviceKeys.Key(user, pk.EccPub); err == ErrNoKey {
+		known = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	snonce, err := randomBytes(NonceSize)
+	if err != nil {
+		return nil, err
+	}
+	token, err := randomBytes(TokenSize)
+	if err != nil {
+		return nil, err
+	}
+	sess := Session{
+		Token:      token,
+		User:       user,
+		DeviceType: req.DeviceType,
+		Keys:       DeriveKeys(secret, pk.ClientNonce, snonce),
+		DeviceKey:  pk.EccPub,
+	}
+	if known {
+		sess.keyLogin = KeyLoginMessage(req.UserId, token, pk.EccPub, pk.DhPub, pk.ClientNonce, pub, snonce)
+	}
+	h.Sessions.add(sess, snonce)
+	return &api.AuthResponse{R: &api.AuthResponse_Auth{Auth: &api.Auth1Response{
+		Token: token,
+		A: &api.Auth1Response_PubKey_{PubKey: &api.Auth1Response_PubKey{
+			EccPub:      pub,
+			ServerNonce: snonce,
+		}},
+	}}}, nil
+}
+
+// what a device signs with its key to log in: everything sent in the
+// handshake, so the signature's no good for any other session. userID is the
+// name as the device sent it
+func KeyLoginMessage(userID string, token, devicePub, clientPub, clientNonce, serverPub, serverNonce []byte) []byte {
+	return signedMessage("chesster device login", []byte(userID), token, devicePub, clientPub, clientNonce, serverPub, serverNonce)
+}
+
+// what a device signs with the key it's registering, so it can't register a
+// key it doesn't hold. token is the session it's registered in
+func RegisterKeyMessage(token, devicePub []byte) []byte {
+	return signedMessage("chesster register key", token, devicePub)
+}
+
+// what, followed by each field prefixed with its length so they can't run
+// into each other
+func signedMessage(what string, fields ...[]byte) []byte {
+	m := []byte(what)
+	for _, f := range fields {
+		var l [4]byte
+		binary.BigEndian.PutUint32(l[:], uint32(len(f)))
+		m = append(append(m, l[:]...), f...)
+	}
+	return m
+}
+
+func (h *Handler) registerKey(ctx context.Context, req *api.RegisterKeyRequest) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	reply := func(e api.RegisterKeyResponse_Error) (*api.AuthResponse, error) {
+		return &api.AuthResponse{R: &api.AuthResponse_RegisterKey{RegisterKey: &api.RegisterKeyResponse{
+			Success: e == api.RegisterKeyResponse_NO_ERROR,
+			Error:   e,
+		}}}, nil
+	}
+	if err := checkKey(req.EccPub); err == ErrBadKey {
+		return reply(api.RegisterKeyResponse_BAD_KEY)
+	} else if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(req.EccPub, RegisterKeyMessage(sess.Token, req.EccPub), req.Signature) {
+		return reply(api.RegisterKeyResponse_BAD_SIGNATURE)
+	}
+	deviceType := req.DeviceType
+	if deviceType == nil {
+		deviceType = sess.DeviceType
+	}
+	err := h.DeviceKeys.AddKey(DeviceKey{User: sess.User, Pub: req.EccPub, DeviceType: deviceType})
+	if err == ErrKeyExists {
+		return reply(api.RegisterKeyResponse_KEY_ALREADY_REGISTERED)
+	} else if err != nil {
+		return nil, err
+	}
+	return reply(api.RegisterKeyResponse_NO_ERROR)
+}
+
+func (h *Handler) listKeys(ctx context.Context) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	ks, err := h.DeviceKeys.Keys(sess.User)
+	if err != nil {
+		return nil, err
+	}
+	l := &api.KeyList{}
+	for _, k := range ks {
+		l.Keys = append(l.Keys, k.Pub)
+		l.DeviceType = append(l.DeviceType, k.DeviceType)
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_ListKeys{ListKeys: l}}, nil
+}
+
+// revokes the user's keys, ending any sessions logged in with them
+func (h *Handler) revokeKeys(ctx context.Context, req *api.RevokeKeysRequest) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	r := &api.RevokeKeysResponse{}
+	for _, k := range req.Keys {
+		err := h.DeviceKeys.RevokeKey(sess.User, k)
+		if err != nil && err != ErrNoKey {
+			return nil, err
+		}
+		if err == nil {
+			if err := h.Sessions.removeKey(sess.User, k); err != nil {
+				return nil, err
+			}
+		}
+		r.Keys = append(r.Keys, k)
+		r.Success = append(r.Success, err == nil)
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_RevokeKeys{RevokeKeys: r}}, nil
+}
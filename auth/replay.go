@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"errors"
+)
+
+const (
+	// how far behind the highest req_id seen a request can be; like IPsec's
+	// anti-replay window, so requests can arrive a little out of order
+	reqIDWindow = 64
+	// how many of the latest server nonces a request can echo, so a client
+	// can have a few requests in flight at once
+	serverNonceWindow = 8
+)
+
+var ErrReplayed = errors.New("req_id or nonce reused")
+
+// what a session has seen and issued, to turn away replayed requests
+type replayWindow struct {
+	started  bool
+	maxReqID uint32
+	// bit i set if maxReqID-i has been seen
+	seen uint64
+	// client nonces of the requests in the window
+	clientNonces map[string]uint32
+	// latest first
+	serverNonces [][]byte
+}
+
+// records a request, failing if its req_id or client nonce was used before or
+// is too old, or it doesn't echo one of the latest server nonces
+func (w *replayWindow) check(reqID uint32, clientNonce, serverNonce []byte) error {
+	fresh := false
+	for _, n := range w.serverNonces {
+		if string(n) == string(serverNonce) {
+			fresh = true
+			break
+		}
+	}
+	if !fresh {
+		return ErrReplayed
+	}
+	if _, ok := w.clientNonces[string(clientNonce)]; ok {
+		return ErrReplayed
+	}
+
+	if !w.started {
+		w.started = true
+		w.maxReqID = reqID
+		w.seen = 1
+	} else if reqID > w.maxReqID {
+		shift := reqID - w.maxReqID
+		if shift >= reqIDWindow {
+			w.seen = 0
+		} else {
+			w.seen <<= shift
+		}
+		w.seen |= 1
+		w.maxReqID = reqID
+	} else {
+		back := w.maxReqID - reqID
+		if back >= reqIDWindow || w.seen&(1<<back) != 0 {
+			return ErrReplayed
+		}
+		w.seen |= 1 << back
+	}
+
+	if w.clientNonces == nil {
+		w.clientNonces = map[string]uint32{}
+	}
+	w.clientNonces[string(clientNonce)] = reqID
+	// anything older is turned away by its req_id anyway
+	for n, id := range w.clientNonces {
+		if w.maxReqID-id >= reqIDWindow {
+			delete(w.clientNonces, n)
+		}
+	}
+	return nil
+}
+
+// remembers a server nonce sent to the client
+func (w *replayWindow) issue(serverNonce []byte) {
+	w.serverNonces = append([][]byte{serverNonce}, w.serverNonces...)
+	if len(w.serverNonces) > serverNonceWindow {
+		w.serverNonces = w.serverNonces[:serverNonceWindow]
+	}
+}
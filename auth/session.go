@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	TokenSize = 32
+	NonceSize = 16
+
+	// how long a token is good for once the handshake is done
+	SessionLifetime = 2 * time.Hour
+	// how long a client has to finish the handshake
+	HandshakeTimeout = time.Minute
+	// most handshakes waiting to be finished at once; starting another one
+	// forgets the oldest
+	MaxHandshakes = 10000
+	// how often Run forgets expired sessions
+	PruneInterval = HandshakeTimeout
+	// how long an expired token is remembered, to report it as expired
+	// rather than unknown
+	expiredGrace = SessionLifetime
+)
+
+var (
+	ErrNoSession      = errors.New("no such session")
+	ErrSessionExpired = errors.New("session expired")
+)
+
+type Session struct {
+	Token      []byte
+	User       string
+	DeviceType []byte
+	Keys       Keys
+	Expiry     time.Time
+	// set once the client has shown it has the keys too
+	Authenticated bool
+	// if every payload after the handshake is encrypted
+	Encrypted bool
+	// guests have no User, and can only spectate and create accounts
+	Guest bool
+	// the device key the session logged in with, if it didn't use a password
+	DeviceKey []byte
+	// what the device key has to sign in Auth2 to finish logging in; nil if
+	// it isn't one of the user's keys
+	keyLogin []byte
+}
+
+// the sessions in progress, by token
+type Sessions struct {
+	// nil for time.Now
+	Clock func() time.Time
+	// optional; authenticated sessions are written through to it
+	Store SessionStore
+
+	mu       sync.Mutex
+	sessions map[string]*sessionEntry
+	// unfinished handshakes, oldest first; some may have been finished or
+	// removed since
+	handshakes []handshake
+}
+
+type handshake struct {
+	token  string
+	expiry time.Time
+}
+
+type sessionEntry struct {
+	Session
+	replay replayWindow
+	// the token this one was refreshed from, until it's first used
+	replaces []byte
+}
+
+func NewSessions() *Sessions {
+	return &Sessions{sessions: map[string]*sessionEntry{}}
+}
+
+func (s *Sessions) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+	return time.Now()
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// starts a session that has until HandshakeTimeout to be authenticated;
+// serverNonce is the one sent back in the handshake
+func (s *Sessions) add(sess Session, serverNonce []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess.Expiry = s.now().Add(HandshakeTimeout)
+	e := &sessionEntry{Session: sess}
+	e.replay.issue(serverNonce)
+	s.sessions[string(sess.Token)] = e
+	s.handshakes = append(s.handshakes, handshake{string(sess.Token), sess.Expiry})
+	for len(s.handshakes) > MaxHandshakes {
+		s.dropHandshake()
+	}
+}
+
+// forgets the oldest handshake if it's still unfinished. They're never saved,
+// so there's nothing to delete from the Store
+func (s *Sessions) dropHandshake() {
+	hs := s.handshakes[0]
+	s.handshakes[0] = handshake{}
+	s.handshakes = s.handshakes[1:]
+	if e, ok := s.sessions[hs.token]; ok && !e.Authenticated && e.Expiry.Equal(hs.expiry) {
+		delete(s.sessions, hs.token)
+	}
+}
+
+// forgets handshakes that weren't finished in time, and sessions that expired
+// long enough ago
+func (s *Sessions) Prune() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for len(s.handshakes) > 0 && !now.Before(s.handshakes[0].expiry) {
+		s.dropHandshake()
+	}
+	cutoff := now.Add(-expiredGrace)
+	for _, e := range s.sessions {
+		if e.Expiry.Before(cutoff) {
+			if err := s.delete(e.Token); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// prunes the sessions every PruneInterval until ctx is cancelled
+func (s *Sessions) Run(ctx context.Context) {
+	t := time.NewTicker(PruneInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := s.Prune(); err != nil {
+				log.Printf("pruning sessions: %s", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Sessions) entry(token []byte) (*sessionEntry, error) {
+	e, ok := s.sessions[string(token)]
+	if !ok {
+		return nil, ErrNoSession
+	}
+	if !s.now().Before(e.Expiry) {
+		return nil, ErrSessionExpired
+	}
+	return e, nil
+}
+
+// looks up a session; expired ones are reported as ErrSessionExpired for a
+// while before being forgotten
+func (s *Sessions) Get(token []byte) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.entry(token)
+	if err != nil {
+		return Session{}, err
+	}
+	return e.Session, nil
+}
+
+// marks a session as authenticated, starting its SessionLifetime; if the
+// random inputs for encryption are given it's turned on too
+func (s *Sessions) authenticate(token, clientInput, serverInput []byte) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.entry(token)
+	if err != nil {
+		return Session{}, err
+	}
+	if clientInput != nil && serverInput != nil {
+		e.Keys.DeriveEncryptionKeys(clientInput, serverInput)
+		e.Encrypted = true
+	}
+	e.Authenticated = true
+	e.Expiry = s.now().Add(SessionLifetime)
+	return e.Session, s.save(e)
+}
+
+// records a request in its session, failing with ErrReplayed if it's been
+// seen before or is too old. The first request in a refreshed session
+// revokes the token it replaced
+func (s *Sessions) checkReplay(token []byte, reqID uint32, clientNonce, serverNonce []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.entry(token)
+	if err != nil {
+		return err
+	}
+	if err := e.replay.check(reqID, clientNonce, serverNonce); err != nil {
+		return err
+	}
+	if e.replaces == nil {
+		return nil
+	}
+	if err := s.delete(e.replaces); err != nil {
+		return err
+	}
+	e.replaces = nil
+	return s.save(e)
+}
+
+// starts a session replacing old with new keys, good for SessionLifetime;
+// old keeps working until the new one is used. serverNonce is the one the
+// first request in it has to echo
+func (s *Sessions) refresh(old []byte, keys Keys, serverNonce []byte) (Session, error) {
+	token, err := randomBytes(TokenSize)
+	if err != nil {
+		return Session{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.entry(old)
+	if err != nil {
+		return Session{}, err
+	}
+	sess := o.Session
+	sess.Token = token
+	sess.Keys = keys
+	sess.Encrypted = keys.ClientEnc != nil
+	sess.Expiry = s.now().Add(SessionLifetime)
+	e := &sessionEntry{Session: sess, replaces: old}
+	e.replay.issue(serverNonce)
+	s.sessions[string(token)] = e
+	return sess, s.save(e)
+}
+
+// a user's authenticated sessions that haven't expired, soonest to expire
+// first
+func (s *Sessions) List(user string) []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var ss []Session
+	for _, e := range s.sessions {
+		if e.User == user && !e.Guest && e.Authenticated && now.Before(e.Expiry) {
+			ss = append(ss, e.Session)
+		}
+	}
+	sort.Slice(ss, func(i, j int) bool { return ss[i].Expiry.Before(ss[j].Expiry) })
+	return ss
+}
+
+// makes a new server nonce for the next reply in a session
+func (s *Sessions) issueNonce(token []byte) ([]byte, error) {
+	n, err := randomBytes(NonceSize)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := s.entry(token)
+	if err != nil {
+		return nil, err
+	}
+	e.replay.issue(n)
+	return n, nil
+}
+
+func (s *Sessions) Remove(token []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.delete(token)
+}
+
+// ends one of a user's sessions, returning false if they don't have one with
+// that token
+func (s *Sessions) removeUser(user string, token []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.sessions[string(token)]
+	if !ok || e.Guest || e.User != user {
+		return false, nil
+	}
+	return true, s.delete(token)
+}
+
+// ends every session a user logged in to with a device key
+func (s *Sessions) removeKey(user string, pub []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.sessions {
+		if e.User == user && e.DeviceKey != nil && string(e.DeviceKey) == string(pub) {
+			if err := s.delete(e.Token); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
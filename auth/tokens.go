@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+
+	api "github.com/cactorium/chesster-server/api"
+	server "github.com/cactorium/chesster-server/server"
+)
+
+// starts a new session with fresh keys to replace the one the request was
+// made in, without going through the handshake again
+func (h *Handler) refreshToken(ctx context.Context, req *api.RefreshTokenRequest) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	if len(req.ClientInput) < NonceSize {
+		return nil, &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: "client input too short"}
+	}
+	serverInput, err := randomBytes(NonceSize)
+	if err != nil {
+		return nil, err
+	}
+	n, err := h.Sessions.refresh(sess.Token, sess.Keys.Refresh(req.ClientInput, serverInput), serverInput)
+	if err == ErrNoSession || err == ErrSessionExpired {
+		return nil, authError("unknown token")
+	} else if err != nil {
+		return nil, err
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_RefreshToken{RefreshToken: &api.RefreshTokenResponse{
+		Token:       n.Token,
+		ServerInput: serverInput,
+		TokenExpiry: uint64(n.Expiry.Unix()),
+	}}}, nil
+}
+
+func (h *Handler) listSessions(ctx context.Context) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	l := &api.SessionList{}
+	for _, s := range h.Sessions.List(sess.User) {
+		l.Sessions = append(l.Sessions, s.Token)
+		l.DeviceType = append(l.DeviceType, s.DeviceType)
+		l.Expiration = append(l.Expiration, uint64(s.Expiry.Unix()))
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_ListSessions{ListSessions: l}}, nil
+}
+
+// ends any of the user's sessions, including the one it's made in
+func (h *Handler) revokeSessions(ctx context.Context, req *api.RevokeSessionsRequest) (*api.AuthResponse, error) {
+	sess, ok := SessionFrom(ctx)
+	if !ok {
+		return nil, authError("no session")
+	}
+	r := &api.RevokeSessionsResponse{}
+	for _, t := range req.Sessions {
+		ok, err := h.Sessions.removeUser(sess.User, t)
+		if err != nil {
+			return nil, err
+		}
+		r.Sessions = append(r.Sessions, t)
+		r.Success = append(r.Success, ok)
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_RevokeSessions{RevokeSessions: r}}, nil
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrNoUser     = errors.New("no such user")
+	ErrUserExists = errors.New("user name already used")
+	ErrBadName    = errors.New("invalid user name")
+)
+
+const (
+	MinNameLength = 3
+	MaxNameLength = 32
+)
+
+type User struct {
+	Name         string
+	Salt         []byte
+	PasswordHash []byte
+}
+
+// where users' login info is kept
+type UserStore interface {
+	// ErrNoUser if there's no user called name
+	User(name string) (User, error)
+	// ErrUserExists if there's already a user called u.Name
+	Add(u User) error
+}
+
+// the name a user is stored under. Names are case insensitive and surrounding
+// spaces are dropped; what's left has to be MinNameLength to MaxNameLength
+// ASCII letters, digits, '_' or '-', starting with a letter
+func NormalizeName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < MinNameLength || len(name) > MaxNameLength {
+		return "", ErrBadName
+	}
+	for i, c := range name {
+		switch {
+		case 'a' <= c && c <= 'z':
+		case i > 0 && ('0' <= c && c <= '9' || c == '_' || c == '-'):
+		default:
+			return "", ErrBadName
+		}
+	}
+	return name, nil
+}
+
+// makes a user with a new salt. The name is normalized, but the password
+// isn't checked; see CheckPassword
+func NewUser(name, password string) (User, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return User{}, err
+	}
+	salt, err := NewSalt()
+	if err != nil {
+		return User{}, err
+	}
+	hash, err := HashPassword(password, salt)
+	if err != nil {
+		return User{}, err
+	}
+	return User{Name: name, Salt: salt, PasswordHash: hash}, nil
+}
+
+// a UserStore that forgets everything on restart; for tests
+type MemoryUsers struct {
+	mu    sync.Mutex
+	users map[string]User
+}
+
+func NewMemoryUsers() *MemoryUsers {
+	return &MemoryUsers{users: map[string]User{}}
+}
+
+func (m *MemoryUsers) Add(u User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[u.Name]; ok {
+		return ErrUserExists
+	}
+	m.users[u.Name] = u
+	return nil
+}
+
+func (m *MemoryUsers) User(name string) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[name]
+	if !ok {
+		return User{}, ErrNoUser
+	}
+	return u, nil
+}
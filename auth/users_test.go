@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+		err  error
+	}{
+		{"alice", "alice", nil},
+		{"  Alice\t", "alice", nil},
+		{"BOB_the-2nd", "bob_the-2nd", nil},
+		{"abc", "abc", nil},
+		{strings.Repeat("a", MaxNameLength), strings.Repeat("a", MaxNameLength), nil},
+		{"ab", "", ErrBadName},
+		{strings.Repeat("a", MaxNameLength+1), "", ErrBadName},
+		{"2bob", "", ErrBadName},
+		{"_bob", "", ErrBadName},
+		{"bob smith", "", ErrBadName},
+		{"bob@example", "", ErrBadName},
+		{"zoë", "", ErrBadName},
+		{"", "", ErrBadName},
+	}
+	for _, c := range cases {
+		got, err := NormalizeName(c.name)
+		if got != c.want || err != c.err {
+			t.Errorf("%q: expected %q, %v got %q, %v", c.name, c.want, c.err, got, err)
+		}
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	cases := []struct {
+		password string
+		err      error
+	}{
+		{"hunter22", nil},
+		{"hunter2", ErrBadPassword},
+		// counted in characters, not bytes
+		{"ééééééé", ErrBadPassword},
+		{"éééééééé", nil},
+		{strings.Repeat("a", MaxPasswordLength), nil},
+		{strings.Repeat("a", MaxPasswordLength+1), ErrBadPassword},
+	}
+	for _, c := range cases {
+		if err := CheckPassword(c.password); err != c.err {
+			t.Errorf("%.20q: expected %v got %v", c.password, c.err, err)
+		}
+	}
+}
+
+func TestMemoryUsers(t *testing.T) {
+	m := NewMemoryUsers()
+	if _, err := m.User("alice"); err != ErrNoUser {
+		t.Errorf("expected %v got %v", ErrNoUser, err)
+	}
+	if err := m.Add(User{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Add(User{Name: "alice"}); err != ErrUserExists {
+		t.Errorf("expected %v got %v", ErrUserExists, err)
+	}
+	if u, err := m.User("alice"); err != nil || u.Name != "alice" {
+		t.Errorf("expected alice got %v, %v", u, err)
+	}
+}
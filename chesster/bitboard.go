@@ -0,0 +1,288 @@
+package chesster
+
+import (
+	"math/bits"
+)
+
+// one bit per square, with square (x, y) at bit y*8+x; used for move
+// generation and check detection
+type bitboards struct {
+	sides [2]uint64
+	types [7]uint64
+}
+
+// the bitboards for a Board along with where each of its pieces is in
+// Pieces, so a piece can be looked up by square. It's built from Pieces on
+// first use and then kept up to date by every move, like the hash
+type boardCache struct {
+	bb bitboards
+	// index in Pieces of the piece on each square plus one, 0 if it's empty
+	squares [64]int16
+	// len(Pieces) and the board's fingerprint when it was last brought up to
+	// date; if pieces were added, removed or changed by hand since then it
+	// gets rebuilt
+	n     int
+	sum   uint64
+	valid bool
+}
+
+func sqIndex(x, y int) int {
+	return y*8 + x
+}
+
+func bit(x, y int) uint64 {
+	return 1 << uint(sqIndex(x, y))
+}
+
+var (
+	knightAttacks [64]uint64
+	kingAttacks   [64]uint64
+	// squares attacked by a pawn of each side
+	pawnAttacks [2][64]uint64
+	// squares in each direction from a square, up to the edge of the board
+	rays [8][64]uint64
+)
+
+// the first four directions increase the square index, the rest decrease it
+var rayDirs = [8][2]int{
+	{1, 0}, {0, 1}, {1, 1}, {-1, 1},
+	{-1, 0}, {0, -1}, {-1, -1}, {1, -1},
+}
+
+var (
+	rookDirs   = []int{0, 1, 4, 5}
+	bishopDirs = []int{2, 3, 6, 7}
+)
+
+func init() {
+	knightDX := []int{1, 1, -1, -1, 2, 2, -2, -2}
+	knightDY := []int{2, -2, 2, -2, 1, -1, 1, -1}
+	kingDX := []int{0, 0, -1, -1, -1, 1, 1, 1}
+	kingDY := []int{1, -1, -1, 0, 1, -1, 0, 1}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			s := sqIndex(x, y)
+			for i := 0; i < 8; i++ {
+				if isInBounds(x+knightDX[i], y+knightDY[i]) {
+					knightAttacks[s] |= bit(x+knightDX[i], y+knightDY[i])
+				}
+				if isInBounds(x+kingDX[i], y+kingDY[i]) {
+					kingAttacks[s] |= bit(x+kingDX[i], y+kingDY[i])
+				}
+			}
+			for _, dx := range []int{-1, 1} {
+				if isInBounds(x+dx, y+1) {
+					pawnAttacks[White][s] |= bit(x+dx, y+1)
+				}
+				if isInBounds(x+dx, y-1) {
+					pawnAttacks[Black][s] |= bit(x+dx, y-1)
+				}
+			}
+			for d, dir := range rayDirs {
+				for nx, ny := x+dir[0], y+dir[1]; isInBounds(nx, ny); nx, ny = nx+dir[0], ny+dir[1] {
+					rays[d][s] |= bit(nx, ny)
+				}
+			}
+		}
+	}
+}
+
+// squares a sliding piece on s can reach, including the first piece it runs
+// into in each direction
+func slidingAttacks(s int, occ uint64, dirs []int) uint64 {
+	var a uint64
+	for _, d := range dirs {
+		ray := rays[d][s]
+		if blockers := ray & occ; blockers != 0 {
+			var first int
+			if d < 4 {
+				first = bits.TrailingZeros64(blockers)
+			} else {
+				first = 63 - bits.LeadingZeros64(blockers)
+			}
+			ray &^= rays[d][first]
+		}
+		a |= ray
+	}
+	return a
+}
+
+func isValidPiece(p Piece) bool {
+	return isInBounds(p.X, p.Y) && p.Type > InvalidPiece && p.Type <= Queen && (p.Side == White || p.Side == Black)
+}
+
+// the board's cache, rebuilding it if it's missing or out of date. Pieces and
+// State can be edited by hand, so that's checked every time; the hash goes
+// along with it
+func (b *Board) boards() *boardCache {
+	if !b.cache.valid || b.cache.n != len(b.Pieces) || b.cache.sum != b.fingerprint() {
+		b.rebuildCache()
+		b.hashValid = false
+	}
+	return &b.cache
+}
+
+func (b *Board) rebuildCache() {
+	b.cache = boardCache{n: len(b.Pieces), sum: b.fingerprint(), valid: true}
+	// backwards, so the first of two pieces on the same square wins like it
+	// would in a search of Pieces
+	for i := len(b.Pieces) - 1; i >= 0; i-- {
+		if isValidPiece(b.Pieces[i]) {
+			b.cache.set(b.Pieces[i], i)
+		}
+	}
+}
+
+// mixes the bits of v; splitmix64's finalizer
+func mix(v uint64) uint64 {
+	v ^= v >> 30
+	v *= 0xbf58476d1ce4e5b9
+	v ^= v >> 27
+	v *= 0x94d049bb133111eb
+	return v ^ v>>31
+}
+
+// a hash of everything the cache and the Zobrist hash are worked out from,
+// including which piece is where in Pieces, to tell if any of it changed
+func (b *Board) fingerprint() uint64 {
+	h := mix(uint64(uint8(b.State)) | uint64(uint8(b.WhiteEnPassant))<<8 | uint64(uint8(b.BlackEnPassant))<<16)
+	for i, p := range b.Pieces {
+		v := uint64(uint8(p.X)) | uint64(uint8(p.Y))<<8 | uint64(uint8(p.Type))<<16 | uint64(uint8(p.Side))<<24 | uint64(i)<<40
+		if p.HasMoved {
+			v |= 1 << 32
+		}
+		h += mix(v)
+	}
+	return h
+}
+
+// empties square sq
+func (c *boardCache) clear(sq int) {
+	c.bb.remove(1 << uint(sq))
+	c.squares[sq] = 0
+}
+
+// puts p on its square, replacing whatever was there, as the piece at index
+// i in Pieces
+func (c *boardCache) set(p Piece, i int) {
+	sq := sqIndex(p.X, p.Y)
+	c.clear(sq)
+	c.bb.sides[p.Side] |= 1 << uint(sq)
+	c.bb.types[p.Type] |= 1 << uint(sq)
+	c.squares[sq] = int16(i + 1)
+}
+
+// the index in Pieces of the piece on (x, y) as far as the cache knows, or -1
+// if it's empty; only for when the cache was just checked
+func (c *boardCache) index(x, y int) int {
+	if !isInBounds(x, y) {
+		return -1
+	}
+	return int(c.squares[sqIndex(x, y)]) - 1
+}
+
+// the index in Pieces of the piece on (x, y), or -1 if it's empty
+func (b *Board) pieceIndex(x, y int) int {
+	if !isInBounds(x, y) {
+		return -1
+	}
+	i := int(b.boards().squares[sqIndex(x, y)]) - 1
+	if i != -1 && (b.Pieces[i].X != x || b.Pieces[i].Y != y) {
+		// shouldn't happen with the fingerprint checked, but a piece that
+		// isn't where the cache says is worse than rebuilding it
+		b.rebuildCache()
+		b.hashValid = false
+		i = int(b.cache.squares[sqIndex(x, y)]) - 1
+	}
+	return i
+}
+
+func (bb *bitboards) occupied() uint64 {
+	return bb.sides[White] | bb.sides[Black]
+}
+
+func (bb *bitboards) king(s Side) (int, bool) {
+	k := bb.types[King] & bb.sides[s]
+	if k == 0 {
+		return 0, false
+	}
+	return bits.TrailingZeros64(k), true
+}
+
+// whether any piece of side s attacks square sq
+func (bb *bitboards) attacked(sq int, s Side) bool {
+	them := bb.sides[s]
+	if pawnAttacks[s.Opposite()][sq]&bb.types[Pawn]&them != 0 {
+		return true
+	}
+	if knightAttacks[sq]&bb.types[Knight]&them != 0 {
+		return true
+	}
+	if kingAttacks[sq]&bb.types[King]&them != 0 {
+		return true
+	}
+	occ := bb.occupied()
+	if rq := (bb.types[Rook] | bb.types[Queen]) & them; rq != 0 && slidingAttacks(sq, occ, rookDirs)&rq != 0 {
+		return true
+	}
+	if bq := (bb.types[Bishop] | bb.types[Queen]) & them; bq != 0 && slidingAttacks(sq, occ, bishopDirs)&bq != 0 {
+		return true
+	}
+	return false
+}
+
+func (bb *bitboards) remove(mask uint64) {
+	bb.sides[White] &^= mask
+	bb.sides[Black] &^= mask
+	for t := range bb.types {
+		bb.types[t] &^= mask
+	}
+}
+
+// moves the pieces the way commitMove would, without checking the move
+func (bb *bitboards) apply(m Move) {
+	s := m.Start.Side
+	from := bit(m.Start.X, m.Start.Y)
+	to := bit(m.End.X, m.End.Y)
+	if m.IsCastle {
+		y := m.Start.Y
+		rfrom, rto := bit(0, y), bit(3, y)
+		if m.IsKingsideCastle {
+			rfrom, rto = bit(7, y), bit(5, y)
+		}
+		bb.remove(from | rfrom)
+		bb.sides[s] |= to | rto
+		bb.types[King] |= to
+		bb.types[Rook] |= rto
+		return
+	}
+	// en passant captures the pawn beside the start square
+	if m.Start.Type == Pawn && m.Start.X != m.End.X && bb.occupied()&to == 0 {
+		bb.remove(bit(m.End.X, m.Start.Y))
+	}
+	bb.remove(from | to)
+	bb.sides[s] |= to
+	bb.types[m.End.Type] |= to
+}
+
+// whether a possible move leaves the moving side's king safe
+func (bb *bitboards) legal(m Move) bool {
+	s := m.Start.Side
+	if m.IsCastle {
+		// the king can't pass through check either
+		tx := 3
+		if m.IsKingsideCastle {
+			tx = 5
+		}
+		if bb.attacked(sqIndex(tx, m.Start.Y), s.Opposite()) {
+			return false
+		}
+	}
+	nb := *bb
+	nb.apply(m)
+	k, ok := nb.king(s)
+	if !ok {
+		return true
+	}
+	return !nb.attacked(k, s.Opposite())
+}
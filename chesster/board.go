@@ -1,7 +1,8 @@
 package chesster
 
 import (
-	"strconv"
+	"fmt"
+	"math/bits"
 )
 
 type PieceType int
@@ -28,7 +29,8 @@ type Move struct {
 	Start       Piece
 	End         Piece
 	IsPromotion bool
-	// if the move is a castle; overrides all the above attributes
+	// if the move is a castle; Start and End are the king's squares, and the
+	// rook is moved along with it
 	IsCastle bool
 	// true is kingside, false if queenside; ignored if not castle
 	IsKingsideCastle bool
@@ -53,6 +55,12 @@ type Board struct {
 	WhiteEnPassant int
 	// -1 if not marked, set if black moved a pawn two spaces last turn, set to the pawn's location
 	BlackEnPassant int
+
+	// cached Zobrist hash, see Hash
+	hash      uint64
+	hashValid bool
+	// cached bitboards, see boardCache
+	cache boardCache
 }
 
 func isInBounds(x, y int) bool {
@@ -120,6 +128,9 @@ func (b *Board) Clone() Board {
 		State:          b.State,
 		WhiteEnPassant: b.WhiteEnPassant,
 		BlackEnPassant: b.BlackEnPassant,
+		hash:           b.hash,
+		hashValid:      b.hashValid,
+		cache:          b.cache,
 	}
 	copy(newBoard.Pieces, b.Pieces)
 	copy(newBoard.Captured, b.Captured)
@@ -130,20 +141,16 @@ func (b *Board) IsMove(s Side) bool {
 	return (s == White && b.State == WhiteMove) || (s == Black && b.State == BlackMove)
 }
 
-func (b *Board) getPiece(x, y int) *Piece {
-	for i, p := range b.Pieces {
-		if p.X == x && p.Y == y {
-			return &b.Pieces[i]
-		}
+func (b *Board) SideToMove() Side {
+	if b.State == BlackMove {
+		return Black
 	}
-	return nil
+	return White
 }
 
-func (b *Board) getKing(s Side) *Piece {
-	for i, p := range b.Pieces {
-		if p.Type == King && p.Side == s {
-			return &b.Pieces[i]
-		}
+func (b *Board) getPiece(x, y int) *Piece {
+	if i := b.pieceIndex(x, y); i != -1 {
+		return &b.Pieces[i]
 	}
 	return nil
 }
@@ -155,56 +162,55 @@ func maxInt(a, b int) int {
 		return b
 	}
 }
-func minInt(a, b int) int {
-	if a < b {
-		return a
-	} else {
-		return b
-	}
-}
 func absInt(a int) int {
 	return maxInt(a, -a)
 }
 
 // gets possible moves
 func (p Piece) GetPossibleMoves(b *Board) []Move {
-	isClear := func(x, y int) bool {
-		return b.getPiece(x, y) == nil
-	}
-	hasEnemy := func(x, y int) bool {
-		maybePiece := b.getPiece(x, y)
-		if maybePiece == nil {
-			return false
+	return p.possibleMoves(b, &b.boards().bb)
+}
+
+func (p Piece) possibleMoves(b *Board, bb *bitboards) []Move {
+	// copy all the ones that don't leave the king in check
+	vm := []Move{}
+	for _, m := range p.pseudoMoves(b, bb) {
+		if bb.legal(m) {
+			vm = append(vm, m)
 		}
-		return maybePiece.Side == p.Side.Opposite()
 	}
-	raycast := func(ary []Move, dx, dy int) []Move {
-		ret := ary
-		x := p.X + dx
-		y := p.Y + dy
-		for isInBounds(x, y) && isClear(x, y) {
-			newPiece := p
-			newPiece.X = x
-			newPiece.Y = y
-			newPiece.HasMoved = true
-			x = x + dx
-			y = y + dy
-			ret = append(ret, Move{Start: p, End: newPiece})
-		}
-		if isInBounds(x, y) && hasEnemy(x, y) {
+	return vm
+}
+
+// the moves the piece could make if it didn't matter what they left its king
+// in, other than castling out of check
+func (p Piece) pseudoMoves(b *Board, bb *bitboards) []Move {
+	if !isValidPiece(p) {
+		return nil
+	}
+	own := bb.sides[p.Side]
+	enemy := bb.sides[p.Side.Opposite()]
+	occ := own | enemy
+	from := sqIndex(p.X, p.Y)
+	isClear := func(x, y int) bool {
+		return occ&bit(x, y) == 0
+	}
+
+	moves := make([]Move, 0)
+	// adds a move to each square in targets
+	add := func(targets uint64) {
+		for targets != 0 {
+			t := bits.TrailingZeros64(targets)
+			targets &= targets - 1
 			newPiece := p
-			newPiece.X = x
-			newPiece.Y = y
+			newPiece.X = t % 8
+			newPiece.Y = t / 8
 			newPiece.HasMoved = true
-			ret = append(ret, Move{Start: p, End: newPiece, Capture: true})
+			moves = append(moves, Move{Start: p, End: newPiece, Capture: enemy&(1<<uint(t)) != 0})
 		}
-		return ret
 	}
 
-	moves := make([]Move, 0)
 	switch p.Type {
-	case InvalidPiece:
-		return nil
 	case Pawn:
 		var forward int
 		if p.Side == White {
@@ -212,198 +218,98 @@ func (p Piece) GetPossibleMoves(b *Board) []Move {
 		} else {
 			forward = -1
 		}
-		// add forward one square if it's not blocked
-		if isInBounds(p.X, p.Y+forward) && isClear(p.X, p.Y+forward) {
+		addPawn := func(x, y int, capture bool) {
 			newPiece := p
-			newPiece.Y = p.Y + forward
+			newPiece.X = x
+			newPiece.Y = y
 			newPiece.HasMoved = true
 			if newPiece.Y == 0 || newPiece.Y == 7 {
 				for _, pieceType := range []PieceType{Rook, Knight, Bishop, Queen} {
 					newNewPiece := newPiece
 					newNewPiece.Type = pieceType
-					moves = append(moves, Move{Start: p, End: newNewPiece, IsPromotion: true})
+					moves = append(moves, Move{Start: p, End: newNewPiece, IsPromotion: true, Capture: capture})
 				}
 			} else {
-				moves = append(moves, Move{Start: p, End: newPiece})
+				moves = append(moves, Move{Start: p, End: newPiece, Capture: capture})
 			}
 		}
-		// add forward two squares if it's not blocked
-		if !p.HasMoved {
-			if isInBounds(p.X, p.Y+2*forward) && isClear(p.X, p.Y+forward) && isClear(p.X, p.Y+2*forward) {
-				newPiece := p
-				newPiece.Y = p.Y + 2*forward
-				newPiece.HasMoved = true
-				moves = append(moves, Move{Start: p, End: newPiece})
+		// add forward one square if it's not blocked
+		if isInBounds(p.X, p.Y+forward) && isClear(p.X, p.Y+forward) {
+			addPawn(p.X, p.Y+forward, false)
+			// add forward two squares if it's not blocked
+			if !p.HasMoved && isInBounds(p.X, p.Y+2*forward) && isClear(p.X, p.Y+2*forward) {
+				addPawn(p.X, p.Y+2*forward, false)
 			}
 		}
 		// check possible captures
-		if isInBounds(p.X-1, p.Y+forward) && hasEnemy(p.X-1, p.Y+forward) {
-			newPiece := p
-			newPiece.X = p.X - 1
-			newPiece.Y = p.Y + forward
-			newPiece.HasMoved = true
-			moves = append(moves, Move{Start: p, End: newPiece, Capture: true})
-		}
-		if isInBounds(p.X+1, p.Y+forward) && hasEnemy(p.X+1, p.Y+forward) {
-			newPiece := p
-			newPiece.X = p.X + 1
-			newPiece.Y = p.Y + forward
-			newPiece.HasMoved = true
-			moves = append(moves, Move{Start: p, End: newPiece, Capture: true})
+		for _, dx := range []int{-1, 1} {
+			if isInBounds(p.X+dx, p.Y+forward) && enemy&bit(p.X+dx, p.Y+forward) != 0 {
+				addPawn(p.X+dx, p.Y+forward, true)
+			}
 		}
 
 		// check for en passant
-		var enPassantFile int
+		var enPassantFile, enPassantRank int
 		if p.Side == White {
-			enPassantFile = b.BlackEnPassant
+			enPassantFile, enPassantRank = b.BlackEnPassant, 4
 		} else {
-			enPassantFile = b.WhiteEnPassant
+			enPassantFile, enPassantRank = b.WhiteEnPassant, 3
 		}
-		if enPassantFile != -1 && (p.X-1 == enPassantFile || p.X+1 == enPassantFile) {
-			if p.Side == White && p.Y == 4 {
-				newPiece := p
-				newPiece.X = enPassantFile
-				newPiece.Y = 5
-				newPiece.HasMoved = true
-				moves = append(moves, Move{Start: p, End: newPiece, Capture: true})
-			}
-			if p.Side == Black && p.Y == 3 {
-				newPiece := p
-				newPiece.X = enPassantFile
-				newPiece.Y = 2
-				newPiece.HasMoved = true
-				moves = append(moves, Move{Start: p, End: newPiece, Capture: true})
-			}
+		if enPassantFile != -1 && p.Y == enPassantRank && absInt(p.X-enPassantFile) == 1 &&
+			enemy&bb.types[Pawn]&bit(enPassantFile, p.Y) != 0 {
+			addPawn(enPassantFile, p.Y+forward, true)
 		}
 	case Rook:
-		// check all four directions
-		moves = raycast(moves, 1, 0)
-		moves = raycast(moves, -1, 0)
-		moves = raycast(moves, 0, 1)
-		moves = raycast(moves, 0, -1)
+		add(slidingAttacks(from, occ, rookDirs) &^ own)
 	case Knight:
-		// check all eight L shapes
-		dx := []int{1, 1, -1, -1, 2, 2, -2, -2}
-		dy := []int{2, -2, 2, -2, 1, -1, 1, -1}
-		for i := 0; i < 8; i++ {
-			if isInBounds(p.X+dx[i], p.Y+dy[i]) && (isClear(p.X+dx[i], p.Y+dy[i]) || hasEnemy(p.X+dx[i], p.Y+dy[i])) {
-				newPiece := p
-				newPiece.X = p.X + dx[i]
-				newPiece.Y = p.Y + dy[i]
-				newPiece.HasMoved = true
-				moves = append(moves, Move{Start: p, End: newPiece, Capture: hasEnemy(p.X+dx[i], p.Y+dy[i])})
-			}
-		}
+		add(knightAttacks[from] &^ own)
 	case Bishop:
-		// check all four directions
-		moves = raycast(moves, 1, -1)
-		moves = raycast(moves, -1, 1)
-		moves = raycast(moves, 1, 1)
-		moves = raycast(moves, -1, -1)
+		add(slidingAttacks(from, occ, bishopDirs) &^ own)
+	case Queen:
+		add((slidingAttacks(from, occ, rookDirs) | slidingAttacks(from, occ, bishopDirs)) &^ own)
 	case King:
-		// check his eight moves
-		dx := []int{0, 0, -1, -1, -1, 1, 1, 1}
-		dy := []int{1, -1, -1, 0, 1, -1, 0, 1}
-		for i := 0; i < 8; i++ {
-			if isInBounds(p.X+dx[i], p.Y+dy[i]) && (isClear(p.X+dx[i], p.Y+dy[i]) || hasEnemy(p.X+dx[i], p.Y+dy[i])) {
+		add(kingAttacks[from] &^ own)
+		// add castling; can't castle out of check
+		if !p.HasMoved && !bb.attacked(from, p.Side.Opposite()) {
+			unmovedRook := func(x int) bool {
+				r := b.getPiece(x, p.Y)
+				return r != nil && r.Type == Rook && r.Side == p.Side && !r.HasMoved
+			}
+			if unmovedRook(0) && isClear(1, p.Y) && isClear(2, p.Y) && isClear(3, p.Y) {
 				newPiece := p
-				newPiece.X = p.X + dx[i]
-				newPiece.Y = p.Y + dy[i]
+				newPiece.X = 2
 				newPiece.HasMoved = true
-				moves = append(moves, Move{Start: p, End: newPiece, Capture: hasEnemy(p.X+dx[i], p.Y+dy[i])})
-			}
-		}
-		// add castling
-		if !p.HasMoved {
-			for _, piece := range b.Pieces {
-				if piece.Type == Rook && piece.Side == p.Side && !piece.HasMoved {
-					if piece.X == 0 && isClear(1, p.Y) && isClear(2, p.Y) && isClear(3, p.Y) {
-						moves = append(moves, Move{IsCastle: true})
-					}
-					if piece.X == 7 && isClear(6, p.Y) && isClear(5, p.Y) {
-						moves = append(moves, Move{IsCastle: true, IsKingsideCastle: true})
-					}
-				}
-			}
-		}
-	case Queen:
-		// check all eight directions
-		moves = raycast(moves, 1, 0)
-		moves = raycast(moves, -1, 0)
-		moves = raycast(moves, 0, 1)
-		moves = raycast(moves, 0, -1)
-
-		moves = raycast(moves, 1, -1)
-		moves = raycast(moves, -1, 1)
-		moves = raycast(moves, 1, 1)
-		moves = raycast(moves, -1, -1)
-	}
-
-	isValid := make([]bool, len(moves))
-	// check for moves that would lead to own check
-
-	for i, m := range moves {
-		if !isInBounds(m.End.X, m.End.Y) {
-			isValid[i] = false
-			continue
-		}
-		if !m.IsCastle {
-			// copy the pieces into a new board, do the move and see if it causes
-			// the same colored king to be in check
-
-			nb := b.Clone()
-			if !nb.commitMove(m) {
-				isValid[i] = false
-				continue
-			}
-			isValid[i] = !nb.InCheck(p.Side)
-		} else {
-			// handle castling
-			// manually move the king to the spot he moves to before castling
-			// before testing castling
-			// using the commitMove approach as above
-			nb := b.Clone()
-			k := nb.getKing(p.Side)
-			if k == nil {
-				isValid[i] = false
-				continue
-			}
-			var kx int
-			if m.IsKingsideCastle {
-				kx = 5
-			} else {
-				kx = 3
-			}
-			k.X = kx
-			if nb.InCheck(p.Side) {
-				isValid[i] = false
-				continue
+				moves = append(moves, Move{Start: p, End: newPiece, IsCastle: true})
 			}
-
-			nb = b.Clone()
-			if !nb.commitMove(m) {
-				isValid[i] = false
-				continue
+			if unmovedRook(7) && isClear(6, p.Y) && isClear(5, p.Y) {
+				newPiece := p
+				newPiece.X = 6
+				newPiece.HasMoved = true
+				moves = append(moves, Move{Start: p, End: newPiece, IsCastle: true, IsKingsideCastle: true})
 			}
-			isValid[i] = !nb.InCheck(p.Side)
 		}
 	}
+	return moves
+}
 
-	// copy all the valid ones
-	vm := []Move{}
-	for i, m := range moves {
-		if isValid[i] {
-			vm = append(vm, m)
+// gets possible moves for every piece on one side
+func (b *Board) GetAllPossibleMoves(s Side) []Move {
+	bb := &b.boards().bb
+	moves := []Move{}
+	for _, p := range b.Pieces {
+		if p.Side == s {
+			moves = append(moves, p.possibleMoves(b, bb)...)
 		}
 	}
-	return vm
+	return moves
 }
 
 func (b *Board) noMoves(s Side) bool {
+	bb := &b.boards().bb
 	// if there are no pieces that can move
 	for _, p := range b.Pieces {
 		if p.Side == s {
-			if len(p.GetPossibleMoves(b)) > 0 {
+			if len(p.possibleMoves(b, bb)) > 0 {
 				return false
 			}
 		}
@@ -419,63 +325,191 @@ func (b *Board) Stalemate(s Side) bool {
 	return !b.InCheck(s) && b.noMoves(s)
 }
 
+// true if neither side can ever checkmate: bare kings, a single knight or
+// bishop, or any number of bishops all on the same color of square
+func (b *Board) InsufficientMaterial() bool {
+	knights, bishops := 0, 0
+	// bishops on light and dark squares
+	colors := [2]int{}
+	for _, p := range b.Pieces {
+		switch p.Type {
+		case King:
+		case Knight:
+			knights++
+		case Bishop:
+			bishops++
+			colors[(p.X+p.Y)%2]++
+		default:
+			return false
+		}
+	}
+	if knights+bishops <= 1 {
+		return true
+	}
+	return knights == 0 && (colors[0] == 0 || colors[1] == 0)
+}
+
+// whether side s has enough material left to checkmate by some series of
+// legal moves; false for a bare king or a dead position
+func (b *Board) CanCheckmate(s Side) bool {
+	if b.InsufficientMaterial() {
+		return false
+	}
+	for _, p := range b.Pieces {
+		if p.Side == s && p.Type != King {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *Board) TryMove(m Move) (bool, InvalidMoveReason) {
+	_, ok, r := b.MakeMove(m)
+	return ok, r
+}
+
+// MakeMove is TryMove, but also returns the record needed to take the move
+// back with UnmakeMove.
+func (b *Board) MakeMove(m Move) (Undo, bool, InvalidMoveReason) {
+	if r := b.checkMove(m, true); r != MoveOkay {
+		return Undo{}, false, r
+	}
+	u, ok := b.makeMove(m)
+	if !ok {
+		return Undo{}, false, AfraidOfCommitment
+	}
+
+	// else it's good
+	return u, true, MoveOkay
+}
+
+// CheckMove says why MakeMove would turn m down, without making it; MoveOkay
+// if it wouldn't. m.Capture isn't checked, so it can be left unset
+func (b *Board) CheckMove(m Move) InvalidMoveReason {
+	return b.checkMove(m, false)
+}
+
+func (b *Board) checkMove(m Move, capture bool) InvalidMoveReason {
 	// sanity checks
 
 	// make sure the piece doesn't change sides
 	if m.Start.Side != m.End.Side {
-		return false, DisloyaltyForbidden
+		return DisloyaltyForbidden
 	}
 
 	// make sure the move is from the right player
 	if (b.State == WhiteMove && m.Start.Side != White) || (b.State == BlackMove && m.Start.Side != Black) {
-		return false, WrongSide
+		return WrongSide
 	}
 
 	// make sure the piece exists
 	if maybePiece := b.getPiece(m.Start.X, m.Start.Y); maybePiece != nil {
 		if maybePiece.Side != m.Start.Side || maybePiece.Type != m.Start.Type {
-			return false, PieceNotFound
+			return PieceNotFound
 		}
 	} else {
-		return false, PieceNotFound
+		return PieceNotFound
 	}
 
-	// make sure it's a possible move
-	moves := m.Start.GetPossibleMoves(b)
-	moveFound := false
-	for _, move := range moves {
-		if move.Eq(m) {
-			moveFound = true
+	// make sure it's a possible move, and then that it doesn't leave the
+	// king in check
+	bb := &b.boards().bb
+	for _, move := range m.Start.pseudoMoves(b, bb) {
+		if !capture {
+			move.Capture = m.Capture
 		}
+		if !move.Eq(m) {
+			continue
+		}
+		if bb.legal(move) {
+			return MoveOkay
+		} else if move.IsCastle {
+			return CantCastle
+		}
+		return StillInCheck
 	}
-	if !moveFound {
-		return false, InvalidMove
+	if m.IsCastle || isCastle(m) {
+		return CantCastle
 	}
+	return InvalidMove
+}
 
-	if !b.commitMove(m) {
-		return false, AfraidOfCommitment
+// kings only move two squares along their home rank to castle
+func isCastle(m Move) bool {
+	home := 0
+	if m.Start.Side == Black {
+		home = 7
 	}
+	return m.Start.Type == King && m.Start.X == 4 && m.Start.Y == home && m.End.Y == home && absInt(m.End.X-m.Start.X) == 2
+}
 
-	// else it's good
-	return true, MoveOkay
+// everything a move changes on the board, so it can be taken back
+type Undo struct {
+	Move Move
+
+	// the moving piece, or the king when castling, and where it was in Pieces
+	moved      Piece
+	movedIndex int
+	// the castling rook, if any
+	rook      Piece
+	rookIndex int
+	// the captured piece, if any
+	captured      Piece
+	capturedIndex int
+
+	state          BoardState
+	whiteEnPassant int
+	blackEnPassant int
+	hash           uint64
+	hashValid      bool
+	cache          boardCache
 }
 
 func (b *Board) commitMove(m Move) bool {
+	_, ok := b.makeMove(m)
+	return ok
+}
+
+// does a move without checking it's possible
+func (b *Board) makeMove(m Move) (Undo, bool) {
+	u := Undo{
+		Move:           m,
+		rookIndex:      -1,
+		capturedIndex:  -1,
+		state:          b.State,
+		whiteEnPassant: b.WhiteEnPassant,
+		blackEnPassant: b.BlackEnPassant,
+		hash:           b.hash,
+		hashValid:      b.hashValid,
+		cache:          *b.boards(),
+	}
+	// keys of the pieces that moved or were removed, and of the state before
+	// the move, for updating the hash
+	var delta uint64
+	if b.hashValid {
+		delta = b.stateKey()
+	}
 	if m.IsCastle {
-		k := b.getKing(m.Start.Side)
-		if k == nil {
-			return false
+		ksq, ok := b.cache.bb.king(m.Start.Side)
+		if !ok {
+			return u, false
 		}
-		var r *Piece
+		u.movedIndex = int(b.cache.squares[ksq]) - 1
+		k := &b.Pieces[u.movedIndex]
 		if m.IsKingsideCastle {
-			r = b.getPiece(7, k.Y)
+			u.rookIndex = b.cache.index(7, k.Y)
 		} else {
-			r = b.getPiece(0, k.Y)
+			u.rookIndex = b.cache.index(0, k.Y)
 		}
-		if r == nil || r.Type != Rook {
-			return false
+		if u.rookIndex == -1 || b.Pieces[u.rookIndex].Type != Rook {
+			u.rookIndex = -1
+			return u, false
 		}
+		r := &b.Pieces[u.rookIndex]
+		u.moved, u.rook = *k, *r
+		b.cache.clear(ksq)
+		b.cache.clear(sqIndex(r.X, r.Y))
+		delta ^= pieceKey(*k) ^ pieceKey(*r)
 		if m.IsKingsideCastle {
 			k.X = 6
 			r.X = 5
@@ -485,29 +519,48 @@ func (b *Board) commitMove(m Move) bool {
 		}
 		k.HasMoved = true
 		r.HasMoved = true
-		return true
-	}
-	moving := b.getPiece(m.Start.X, m.Start.Y)
-	if moving == nil || moving.Side != m.Start.Side || moving.Type != m.Start.Type {
-		return false
-	}
-	captured := b.getPiece(m.End.X, m.End.Y)
-	// special case en passant
-	if m.Start.Type == Pawn && (absInt(m.End.Y-m.Start.Y) == 2 && absInt(m.End.X-m.Start.X) == 1) {
-		var d int
-		if m.End.Y > m.Start.Y {
-			d = 1
+		b.cache.set(*k, u.movedIndex)
+		b.cache.set(*r, u.rookIndex)
+		delta ^= pieceKey(*k) ^ pieceKey(*r)
+		b.WhiteEnPassant = -1
+		b.BlackEnPassant = -1
+		if b.State == WhiteMove {
+			b.State = BlackMove
 		} else {
-			d = -1
+			b.State = WhiteMove
 		}
-		captured = b.getPiece(m.End.X, m.Start.Y+d)
-		if captured == nil || captured.Type != Pawn || captured.Side != m.Start.Side.Opposite() {
-			return false
+		b.updateHash(delta)
+		b.cache.sum = b.fingerprint()
+		return u, true
+	}
+	u.movedIndex = b.cache.index(m.Start.X, m.Start.Y)
+	if u.movedIndex == -1 {
+		return u, false
+	}
+	moving := &b.Pieces[u.movedIndex]
+	if moving.Side != m.Start.Side || moving.Type != m.Start.Type || !isInBounds(m.End.X, m.End.Y) {
+		return u, false
+	}
+	u.capturedIndex = b.cache.index(m.End.X, m.End.Y)
+	// special case en passant; the pawn moves diagonally onto an empty square
+	// and captures the pawn beside it
+	if m.Start.Type == Pawn && u.capturedIndex == -1 && absInt(m.End.X-m.Start.X) == 1 {
+		u.capturedIndex = b.cache.index(m.End.X, m.Start.Y)
+		if u.capturedIndex == -1 || b.Pieces[u.capturedIndex].Type != Pawn {
+			u.capturedIndex = -1
+			return u, false
 		}
 	}
-	if captured != nil && captured.Side != m.Start.Side.Opposite() {
-		return false
+	var captured *Piece
+	if u.capturedIndex != -1 {
+		captured = &b.Pieces[u.capturedIndex]
+		if captured.Side != m.Start.Side.Opposite() {
+			u.capturedIndex = -1
+			return u, false
+		}
+		u.captured = *captured
 	}
+	u.moved = *moving
 
 	b.WhiteEnPassant = -1
 	b.BlackEnPassant = -1
@@ -520,153 +573,72 @@ func (b *Board) commitMove(m Move) bool {
 		}
 	}
 
+	delta ^= pieceKey(*moving)
 	*moving = m.End
 	moving.HasMoved = true
+	delta ^= pieceKey(*moving)
+	b.cache.clear(sqIndex(m.Start.X, m.Start.Y))
+	b.cache.set(*moving, u.movedIndex)
 	if captured != nil {
+		delta ^= pieceKey(*captured)
 		// save it to the captured list
 		b.Captured = append(b.Captured, *captured)
+		if !(captured.X == m.End.X && captured.Y == m.End.Y) {
+			// en passant, so its square is left empty
+			b.cache.clear(sqIndex(captured.X, captured.Y))
+		}
 		// replace it with the last piece and shift it all down one to remove it
-		*captured = b.Pieces[len(b.Pieces)-1]
-		b.Pieces = b.Pieces[:len(b.Pieces)-1]
+		last := len(b.Pieces) - 1
+		*captured = b.Pieces[last]
+		b.Pieces = b.Pieces[:last]
+		if u.capturedIndex != last {
+			b.cache.set(*captured, u.capturedIndex)
+		}
+		b.cache.n = len(b.Pieces)
 	}
 	if b.State == WhiteMove {
 		b.State = BlackMove
 	} else {
 		b.State = WhiteMove
 	}
-	return true
+	b.updateHash(delta)
+	b.cache.sum = b.fingerprint()
+	return u, true
 }
 
-func (b *Board) InCheck(s Side) bool {
-	king := b.getKing(s)
-	if king == nil {
-		// NOTE: probably should print out some error info
-		return false
-	}
-	kx := king.X
-	ky := king.Y
-
-	isBlocking := func(x, y int) bool {
-		return b.getPiece(x, y) != nil
-	}
-	checkRook := func(px, py int) bool {
-		if px == kx {
-			start := minInt(py, ky) + 1
-			end := maxInt(py, ky)
-
-			canThreaten := true
-			x := kx
-			for y := start; y < end; y++ {
-				if isBlocking(x, y) {
-					canThreaten = false
-					break
-				}
-			}
-			if canThreaten {
-				return true
-			}
-		}
-		if py == ky {
-			start := minInt(px, kx) + 1
-			end := maxInt(px, kx)
-
-			canThreaten := true
-			y := ky
-			for x := start; x < end; x++ {
-				if isBlocking(x, y) {
-					canThreaten = false
-					break
-				}
-			}
-			if canThreaten {
-				return true
-			}
+// UnmakeMove takes back the last move done on the board.
+func (b *Board) UnmakeMove(u Undo) {
+	if u.capturedIndex != -1 {
+		// the last piece was moved into the captured piece's slot; move it
+		// back to the end
+		if u.capturedIndex == len(b.Pieces) {
+			b.Pieces = append(b.Pieces, u.captured)
+		} else {
+			b.Pieces = append(b.Pieces, b.Pieces[u.capturedIndex])
+			b.Pieces[u.capturedIndex] = u.captured
 		}
-		return false
+		b.Captured = b.Captured[:len(b.Captured)-1]
 	}
-	checkBishop := func(px, py int) bool {
-		// on the +x +y diagonal, the difference between x and y stays the same
-		// on the +x -y diagonal, their sum stays the same
-		if kx-ky == px-px {
-			sx := minInt(px, kx) + 1
-			y := minInt(py, ky) + 1
-			ex := maxInt(px, ky)
-			canThreaten := true
-			for x := sx; x < ex; x++ {
-				if isBlocking(x, y) {
-					canThreaten = false
-					break
-				}
-				y++
-			}
-			if canThreaten {
-				return true
-			}
-		}
-		if kx+ky == px+py {
-			sx := minInt(px, kx) + 1
-			y := maxInt(py, ky) - 1
-			ex := maxInt(px, ky)
-			canThreaten := true
-			for x := sx; x < ex; x++ {
-				if isBlocking(x, y) {
-					canThreaten = false
-					break
-				}
-				y--
-			}
-			if canThreaten {
-				return true
-			}
-		}
+	b.Pieces[u.movedIndex] = u.moved
+	if u.rookIndex != -1 {
+		b.Pieces[u.rookIndex] = u.rook
+	}
+	b.State = u.state
+	b.WhiteEnPassant = u.whiteEnPassant
+	b.BlackEnPassant = u.blackEnPassant
+	b.hash = u.hash
+	b.hashValid = u.hashValid
+	b.cache = u.cache
+}
 
+func (b *Board) InCheck(s Side) bool {
+	bb := &b.boards().bb
+	k, ok := bb.king(s)
+	if !ok {
+		// NOTE: probably should print out some error info
 		return false
 	}
-	for _, p := range b.Pieces {
-		// no team kills; probably the least realistic part of chess
-		if p.Side == s {
-			continue
-		}
-		switch p.Type {
-		case Pawn:
-			var f int
-			if p.Side == White {
-				f = 1
-			} else {
-				f = -1
-			}
-			if absInt(p.X-kx) == 1 && p.Y == ky-f {
-				return true
-			}
-		case Rook:
-			if checkRook(p.X, p.Y) {
-				return true
-			}
-		case Knight:
-			if absInt(p.X-kx) == 1 && absInt(p.Y-ky) == 2 {
-				return true
-			}
-			if absInt(p.X-kx) == 2 && absInt(p.Y-ky) == 1 {
-				return true
-			}
-		case Bishop:
-			if checkBishop(p.X, p.Y) {
-				return true
-			}
-		case Queen:
-			if checkRook(p.X, p.Y) {
-				return true
-			}
-			if checkBishop(p.X, p.Y) {
-				return true
-			}
-		case King:
-			if absInt(p.X-kx) <= 1 && absInt(p.Y-ky) <= 1 {
-				return true
-			}
-		}
-	}
-	return false
+	return bb.attacked(k, s.Opposite())
 }
 
 type Side int
@@ -710,50 +682,24 @@ const (
 	CantCastle
 )
 
-func (m Move) Notation(b *Board) string {
-	// TODO: make more correct; this currently outputs more info than needed
-	if m.IsCastle {
-		if m.IsKingsideCastle {
-			return "O-O"
-		} else {
-			return "O-O-O"
-		}
-	}
-	s := ""
-	switch m.Start.Type {
-	default:
-		s += "?"
-	case Pawn:
-		s += ""
-	case Rook:
-		s += "R"
-	case Knight:
-		s += "N"
-	case Bishop:
-		s += "B"
-	case Queen:
-		s += "Q"
-	case King:
-		s += "K"
-	}
-	f := "abcdefgh"
-	if m.Start.X < 0 || m.Start.X > 7 {
-		s += "?"
-	} else {
-		s += string(f[m.Start.X])
-	}
-	s += strconv.FormatInt(int64(m.Start.Y+1), 10)
-
-	if m.Capture {
-		s += "x"
-	}
+var invalidMoveReasonNames = []string{
+	"MoveOkay",
+	"GameEnded",
+	"DisloyaltyForbidden",
+	"WrongSide",
+	"OutOfBounds",
+	"PieceNotFound",
+	"TypeChangeNotAllowed",
+	"InvalidMove",
+	"StillInCheck",
+	"OnlyOneKing",
+	"AfraidOfCommitment",
+	"CantCastle",
+}
 
-	if m.End.X < 0 || m.End.X > 7 {
-		s += "?"
-	} else {
-		s += string(f[m.End.X])
+func (r InvalidMoveReason) String() string {
+	if r < 0 || int(r) >= len(invalidMoveReasonNames) {
+		return fmt.Sprintf("InvalidMoveReason(%d)", int(r))
 	}
-	s += strconv.FormatInt(int64(m.End.Y+1), 10)
-
-	return s
+	return invalidMoveReasonNames[r]
 }
@@ -112,5 +112,44 @@ func TestValidCastle(t *testing.T) {
 
 }
 
+func TestCheckMove(t *testing.T) {
+	// the piece on (x, y) moving to (ex, ey)
+	move := func(b *Board, x, y, ex, ey int) Move {
+		p := *b.getPiece(x, y)
+		e := p
+		e.X, e.Y, e.HasMoved = ex, ey, true
+		return Move{Start: p, End: e}
+	}
+	castle := func(b *Board) Move {
+		m := move(b, 4, 0, 6, 0)
+		m.IsCastle, m.IsKingsideCastle = true, true
+		return m
+	}
+	cases := []struct {
+		fen      string
+		m        func(b *Board) Move
+		expected InvalidMoveReason
+	}{
+		// the bishop's pinned to its king
+		{"4k3/4r3/8/8/8/8/4B3/4K2R w K - 0 1", func(b *Board) Move { return move(b, 4, 1, 3, 2) }, StillInCheck},
+		{"4k3/4r3/8/8/8/8/4B3/4K2R w K - 0 1", func(b *Board) Move { return move(b, 4, 1, 4, 3) }, InvalidMove},
+		{"4k3/4r3/8/8/8/8/4B3/4K2R w K - 0 1", castle, MoveOkay},
+		// through check
+		{"4k3/5r2/8/8/8/8/8/4K2R w K - 0 1", castle, CantCastle},
+		// out of check, without saying it's a castle
+		{"4k3/4r3/8/8/8/8/8/4K2R w K - 0 1", func(b *Board) Move { return move(b, 4, 0, 6, 0) }, CantCastle},
+		{"4k3/8/8/8/8/8/8/4K2R w - - 0 1", castle, CantCastle},
+	}
+	for i, c := range cases {
+		g, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := g.Board.CheckMove(c.m(&g.Board)); r != c.expected {
+			t.Errorf("case %d: expected %s got %s", i, c.expected, r)
+		}
+	}
+}
+
 // TODO check pawns
 // lots of special cases there
@@ -0,0 +1,290 @@
+package chesster
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+var (
+	ErrFENFields    = errors.New("FEN must have four or six fields")
+	ErrFENPlacement = errors.New("FEN has bad piece placement")
+	ErrFENSide      = errors.New("FEN has bad side to move")
+	ErrFENCastling  = errors.New("FEN has bad castling availability")
+	ErrFENEnPassant = errors.New("FEN has bad en passant target")
+	ErrFENClock     = errors.New("FEN has bad move counters")
+	ErrFENCheck     = errors.New("FEN has the side not to move in check")
+)
+
+var fenLetters = map[PieceType]byte{
+	Pawn:   'p',
+	Rook:   'r',
+	Knight: 'n',
+	Bishop: 'b',
+	King:   'k',
+	Queen:  'q',
+}
+
+func fenPiece(c byte) (PieceType, Side, bool) {
+	s := White
+	if c >= 'a' && c <= 'z' {
+		s = Black
+	} else {
+		c += 'a' - 'A'
+	}
+	for t, l := range fenLetters {
+		if l == c {
+			return t, s, true
+		}
+	}
+	return InvalidPiece, White, false
+}
+
+// whether a piece on this square could not have moved yet; kings and rooks
+// are handled by the castling field instead
+func onHomeSquare(t PieceType, s Side, x, y int) bool {
+	home, pawnHome := 0, 1
+	if s == Black {
+		home, pawnHome = 7, 6
+	}
+	switch t {
+	case Pawn:
+		return y == pawnHome
+	case Knight:
+		return y == home && (x == 1 || x == 6)
+	case Bishop:
+		return y == home && (x == 2 || x == 5)
+	case Queen:
+		return y == home && x == 3
+	}
+	return false
+}
+
+// ParseBoardFEN reads the placement, side to move, castling and en passant
+// fields of a FEN record; the move counters are optional and ignored.
+func ParseBoardFEN(fen string) (Board, error) {
+	b := EmptyBoard()
+	fields := strings.Fields(fen)
+	if len(fields) != 4 && len(fields) != 6 {
+		return b, ErrFENFields
+	}
+
+	ranks := strings.Split(fields[0], "/")
+	if len(ranks) != 8 {
+		return b, ErrFENPlacement
+	}
+	for i, rank := range ranks {
+		y := 7 - i
+		x := 0
+		for j := 0; j < len(rank); j++ {
+			c := rank[j]
+			if c >= '1' && c <= '8' {
+				x += int(c - '0')
+				continue
+			}
+			t, s, ok := fenPiece(c)
+			if !ok || x > 7 {
+				return b, ErrFENPlacement
+			}
+			if t == Pawn && (y == 0 || y == 7) {
+				return b, ErrFENPlacement
+			}
+			b.Pieces = append(b.Pieces, Piece{x, y, t, s, !onHomeSquare(t, s, x, y)})
+			x++
+		}
+		if x != 8 {
+			return b, ErrFENPlacement
+		}
+	}
+	for _, s := range []Side{White, Black} {
+		kings := 0
+		for _, p := range b.Pieces {
+			if p.Type == King && p.Side == s {
+				kings++
+			}
+		}
+		if kings != 1 {
+			return b, ErrFENPlacement
+		}
+	}
+
+	switch fields[1] {
+	case "w":
+		b.State = WhiteMove
+	case "b":
+		b.State = BlackMove
+	default:
+		return b, ErrFENSide
+	}
+
+	if fields[2] != "-" {
+		for j := 0; j < len(fields[2]); j++ {
+			var s Side
+			var rx int
+			switch fields[2][j] {
+			case 'K':
+				s, rx = White, 7
+			case 'Q':
+				s, rx = White, 0
+			case 'k':
+				s, rx = Black, 7
+			case 'q':
+				s, rx = Black, 0
+			default:
+				return b, ErrFENCastling
+			}
+			y := 0
+			if s == Black {
+				y = 7
+			}
+			k := b.getPiece(4, y)
+			r := b.getPiece(rx, y)
+			if k == nil || k.Type != King || k.Side != s || r == nil || r.Type != Rook || r.Side != s {
+				return b, ErrFENCastling
+			}
+			k.HasMoved = false
+			r.HasMoved = false
+		}
+	}
+
+	b.WhiteEnPassant = -1
+	b.BlackEnPassant = -1
+	if fields[3] != "-" {
+		ep := fields[3]
+		if len(ep) != 2 || ep[0] < 'a' || ep[0] > 'h' {
+			return b, ErrFENEnPassant
+		}
+		x := int(ep[0] - 'a')
+		// the target square is the one the pawn skipped over
+		if b.State == BlackMove && ep[1] == '3' {
+			if p := b.getPiece(x, 3); p == nil || p.Type != Pawn || p.Side != White {
+				return b, ErrFENEnPassant
+			}
+			b.WhiteEnPassant = x
+		} else if b.State == WhiteMove && ep[1] == '6' {
+			if p := b.getPiece(x, 4); p == nil || p.Type != Pawn || p.Side != Black {
+				return b, ErrFENEnPassant
+			}
+			b.BlackEnPassant = x
+		} else {
+			return b, ErrFENEnPassant
+		}
+	}
+
+	// the side that just moved can't have left its king in check
+	if b.InCheck(b.SideToMove().Opposite()) {
+		return b, ErrFENCheck
+	}
+
+	return b, nil
+}
+
+// ParseFEN creates a game starting from the position in a FEN record.
+func ParseFEN(fen string) (Game, error) {
+	b, err := ParseBoardFEN(fen)
+	if err != nil {
+		return Game{}, err
+	}
+	halfmoves, fullmoves := 0, 1
+	if fields := strings.Fields(fen); len(fields) == 6 {
+		halfmoves, err = strconv.Atoi(fields[4])
+		if err != nil || halfmoves < 0 {
+			return Game{}, ErrFENClock
+		}
+		fullmoves, err = strconv.Atoi(fields[5])
+		if err != nil || fullmoves < 1 {
+			return Game{}, ErrFENClock
+		}
+	}
+
+	g := NewGame()
+	g.Board = b
+	g.MovesSinceCapture = halfmoves
+	g.StartPly = 2 * (fullmoves - 1)
+	if b.State == BlackMove {
+		g.StartPly++
+	}
+	g.Positions = []Position{b.Position()}
+	g.StartFEN = g.ToFEN()
+	g.updateChecks()
+	return g, nil
+}
+
+func (b *Board) fenFields() string {
+	var sb strings.Builder
+	for y := 7; y >= 0; y-- {
+		empty := 0
+		for x := 0; x < 8; x++ {
+			p := b.getPiece(x, y)
+			if p == nil {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			c := fenLetters[p.Type]
+			if p.Side == White {
+				c -= 'a' - 'A'
+			}
+			sb.WriteByte(c)
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+		if y > 0 {
+			sb.WriteByte('/')
+		}
+	}
+
+	if b.State == WhiteMove {
+		sb.WriteString(" w ")
+	} else {
+		sb.WriteString(" b ")
+	}
+
+	castling := ""
+	if b.canCastle(White, true) {
+		castling += "K"
+	}
+	if b.canCastle(White, false) {
+		castling += "Q"
+	}
+	if b.canCastle(Black, true) {
+		castling += "k"
+	}
+	if b.canCastle(Black, false) {
+		castling += "q"
+	}
+	if castling == "" {
+		castling = "-"
+	}
+	sb.WriteString(castling)
+
+	if b.State == BlackMove && b.WhiteEnPassant != -1 {
+		fmt.Fprintf(&sb, " %c3", 'a'+b.WhiteEnPassant)
+	} else if b.State == WhiteMove && b.BlackEnPassant != -1 {
+		fmt.Fprintf(&sb, " %c6", 'a'+b.BlackEnPassant)
+	} else {
+		sb.WriteString(" -")
+	}
+	return sb.String()
+}
+
+// ToFEN writes the board as a FEN record with the move counters reset.
+func (b *Board) ToFEN() string {
+	return b.fenFields() + " 0 1"
+}
+
+// number of the move currently being played, starting at 1
+func (g *Game) FullMoves() int {
+	return (g.StartPly+len(g.Moves))/2 + 1
+}
+
+func (g *Game) ToFEN() string {
+	return fmt.Sprintf("%s %d %d", g.Board.fenFields(), g.MovesSinceCapture, g.FullMoves())
+}
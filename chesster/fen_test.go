@@ -0,0 +1,84 @@
+package chesster
+
+import (
+	"testing"
+)
+
+func TestFENStart(t *testing.T) {
+	b, err := ParseBoardFEN(StartFEN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nb := NewBoard()
+	if len(b.Pieces) != len(nb.Pieces) {
+		t.Fatalf("expected %d got %d", len(nb.Pieces), len(b.Pieces))
+	}
+	for _, p := range nb.Pieces {
+		q := b.getPiece(p.X, p.Y)
+		if q == nil || *q != p {
+			t.Errorf("expected %v got %v", p, q)
+		}
+	}
+	if b.Position() != nb.Position() {
+		t.Errorf("positions differ")
+	}
+	if s := nb.ToFEN(); s != StartFEN {
+		t.Errorf("expected %s got %s", StartFEN, s)
+	}
+}
+
+func TestFENRoundTrip(t *testing.T) {
+	fens := []string{
+		StartFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2",
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"r3k2r/8/8/8/8/8/8/R3K2R b Kq - 17 42",
+	}
+	for _, fen := range fens {
+		g, err := ParseFEN(fen)
+		if err != nil {
+			t.Errorf("%s: %s", fen, err)
+			continue
+		}
+		if s := g.ToFEN(); s != fen {
+			t.Errorf("expected %s got %s", fen, s)
+		}
+	}
+}
+
+func TestFENAfterMoves(t *testing.T) {
+	g := NewGame()
+	e4 := Move{Start: Piece{4, 1, Pawn, White, false}, End: Piece{4, 3, Pawn, White, true}}
+	nf6 := Move{Start: Piece{6, 7, Knight, Black, false}, End: Piece{5, 5, Knight, Black, true}}
+	for _, m := range []Move{e4, nf6} {
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move rejected: %d", r)
+		}
+	}
+	expected := "rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2"
+	if s := g.ToFEN(); s != expected {
+		t.Errorf("expected %s got %s", expected, s)
+	}
+}
+
+func TestFENInvalid(t *testing.T) {
+	fens := []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkqX - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - -1 1",
+		"rnbq1bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQ - 0 1",
+		// black to move can't take the white king
+		"4k3/8/8/8/8/8/8/r3K3 b - - 0 1",
+	}
+	for _, fen := range fens {
+		if _, err := ParseFEN(fen); err == nil {
+			t.Errorf("%q: expected error", fen)
+		}
+	}
+}
@@ -1,5 +1,9 @@
 package chesster
 
+import (
+	"fmt"
+)
+
 type GameState int
 
 const (
@@ -22,8 +26,32 @@ const (
 	Draw50Moves
 	// FIDE rule; threefold repetition
 	Draw3Fold
+	// FIDE rule; neither side can checkmate, or the side whose time ran out
+	// can't be checkmated
+	DrawInsufficientMaterial
 )
 
+var gameStateNames = []string{
+	"InPlay",
+	"WhiteCheckmate",
+	"BlackCheckmate",
+	"WhiteStalemate",
+	"BlackStalemate",
+	"WhiteResigned",
+	"BlackResigned",
+	"DrawAgreed",
+	"Draw50Moves",
+	"Draw3Fold",
+	"DrawInsufficientMaterial",
+}
+
+func (s GameState) String() string {
+	if s < 0 || int(s) >= len(gameStateNames) {
+		return fmt.Sprintf("GameState(%d)", int(s))
+	}
+	return gameStateNames[s]
+}
+
 type Game struct {
 	Moves []Move
 	Board Board
@@ -37,18 +65,51 @@ type Game struct {
 	// black asked for draw
 	BlackDrawAsk      bool
 	MovesSinceCapture int
+	// half moves played before the first entry in Moves, for games that
+	// didn't start from the initial position
+	StartPly int
+	// FEN of the position before the first entry in Moves; empty for the
+	// initial position
+	StartFEN string
+	// every position reached so far, starting with the initial one
+	Positions []Position
+	// if set, threefold repetition has to be claimed with ClaimDraw3Fold
+	// instead of ending the game automatically
+	Claim3Fold bool
+	// the same for the 50-move rule, with ClaimDraw50Moves
+	Claim50Moves bool
+
+	// one for each entry in Moves, for Undo
+	undos []gameUndo
+}
+
+// what a move changed in the game, so it can be taken back
+type gameUndo struct {
+	board             Undo
+	state             GameState
+	whiteCheck        bool
+	blackCheck        bool
+	whiteDrawAsk      bool
+	blackDrawAsk      bool
+	movesSinceCapture int
 }
 
 func NewGame() Game {
+	b := NewBoard()
 	return Game{
 		Moves:             []Move{},
-		Board:             NewBoard(),
+		Board:             b,
 		State:             InPlay,
 		WhiteCheck:        false,
 		BlackCheck:        false,
 		WhiteDrawAsk:      false,
 		BlackDrawAsk:      false,
 		MovesSinceCapture: 0,
+		StartPly:          0,
+		StartFEN:          "",
+		Positions:         []Position{b.Position()},
+		Claim3Fold:        false,
+		Claim50Moves:      false,
 	}
 }
 
@@ -62,8 +123,15 @@ func (g *Game) Clone() Game {
 		WhiteDrawAsk:      g.WhiteDrawAsk,
 		BlackDrawAsk:      g.BlackDrawAsk,
 		MovesSinceCapture: g.MovesSinceCapture,
+		StartPly:          g.StartPly,
+		StartFEN:          g.StartFEN,
+		Positions:         make([]Position, len(g.Positions)),
+		Claim3Fold:        g.Claim3Fold,
+		Claim50Moves:      g.Claim50Moves,
 	}
 	copy(newGame.Moves, g.Moves)
+	copy(newGame.Positions, g.Positions)
+	newGame.undos = append([]gameUndo(nil), g.undos...)
 	return newGame
 }
 
@@ -80,9 +148,12 @@ func (g *Game) BlackWon() bool {
 }
 
 func (g *Game) Draw() bool {
-	return (g.State == DrawAgreed) || (g.State == Draw50Moves) || (g.State == Draw3Fold)
+	return (g.State == DrawAgreed) || (g.State == Draw50Moves) || (g.State == Draw3Fold) ||
+		(g.State == DrawInsufficientMaterial)
 }
 
+// offers a draw for side s, or accepts the other side's. The offer stands
+// until the other side moves
 func (g *Game) OfferDraw(s Side) {
 	if s == White {
 		g.WhiteDrawAsk = true
@@ -95,6 +166,7 @@ func (g *Game) OfferDraw(s Side) {
 	}
 }
 
+// takes back side s's draw offer, if the game hasn't been drawn by it yet
 func (g *Game) RescindDraw(s Side) {
 	if g.State != DrawAgreed {
 		if s == White {
@@ -105,54 +177,219 @@ func (g *Game) RescindDraw(s Side) {
 	}
 }
 
-func (g *Game) DoMove(m Move) (b bool, r InvalidMoveReason) {
-	ocl := len(g.Board.Captured)
-	// do move and update board state as needed
-	if b, r = g.Board.TryMove(m); !b {
-		return
+// side s gives up; returns false if the game's already over
+func (g *Game) Resign(s Side) bool {
+	if g.State != InPlay {
+		return false
+	}
+	if s == White {
+		g.State = WhiteResigned
+	} else {
+		g.State = BlackResigned
 	}
+	return true
+}
 
-	// append to movelist
-	g.Moves = append(g.Moves, m)
+// for when clocks exist; call when side s runs out of time. If the other side
+// can't checkmate by any series of legal moves the game is drawn and FlagFall
+// returns true; otherwise s lost on time and it's up to the caller to record
+// that, since there's no state for timeouts yet
+func (g *Game) FlagFall(s Side) bool {
+	if g.State != InPlay || g.Board.CanCheckmate(s.Opposite()) {
+		return false
+	}
+	g.State = DrawInsufficientMaterial
+	return true
+}
+
+// number of times the current position has occurred, including now
+func (g *Game) Repetitions() int {
+	if len(g.Positions) == 0 {
+		return 1
+	}
+	cur := g.Positions[len(g.Positions)-1]
+	n := 0
+	for _, pos := range g.Positions {
+		if pos == cur {
+			n++
+		}
+	}
+	return n
+}
+
+func (g *Game) CanClaimDraw3Fold() bool {
+	return g.State == InPlay && g.Repetitions() >= 3
+}
+
+// claims a draw by threefold repetition for s; returns false if the current
+// position hasn't occurred three times, or if it isn't s's move, since only
+// the player on move can claim it
+func (g *Game) ClaimDraw3Fold(s Side) bool {
+	if !g.CanClaimDraw3Fold() || !g.Board.IsMove(s) {
+		return false
+	}
+	g.State = Draw3Fold
+	return true
+}
+
+func (g *Game) CanClaimDraw50Moves() bool {
+	return g.State == InPlay && g.MovesSinceCapture >= 100
+}
+
+// claims a draw by the 50-move rule for s; like ClaimDraw3Fold only the
+// player on move can
+func (g *Game) ClaimDraw50Moves(s Side) bool {
+	if !g.CanClaimDraw50Moves() || !g.Board.IsMove(s) {
+		return false
+	}
+	g.State = Draw50Moves
+	return true
+}
 
+// updates the check flags and looks for checkmate or stalemate; returns true
+// if the game ended
+func (g *Game) updateChecks() bool {
 	// check for check
 	g.BlackCheck = g.Board.InCheck(Black)
 	g.WhiteCheck = g.Board.InCheck(White)
 
 	// check for checkmate and stalemate
 	if g.Board.IsMove(White) {
-		if g.Board.Stalemate(White) {
-			g.State = WhiteStalemate
-			return
+		if !g.Board.noMoves(White) {
+			return false
 		}
-		if g.Board.Checkmate(White) {
+		if g.WhiteCheck {
 			g.State = BlackCheckmate
-			return
+		} else {
+			g.State = WhiteStalemate
 		}
 	} else {
-		if g.Board.Stalemate(Black) {
-			g.State = BlackStalemate
-			return
+		if !g.Board.noMoves(Black) {
+			return false
 		}
-		if g.Board.Checkmate(Black) {
+		if g.BlackCheck {
 			g.State = WhiteCheckmate
-			return
+		} else {
+			g.State = BlackStalemate
 		}
 	}
+	return true
+}
+
+func (g *Game) DoMove(m Move) (b bool, r InvalidMoveReason) {
+	if g.GameEnded() {
+		return false, GameEnded
+	}
+	ocl := len(g.Board.Captured)
+	// games built without NewGame don't have their starting position yet
+	if len(g.Positions) == 0 {
+		g.Positions = append(g.Positions, g.Board.Position())
+	}
+	undo := gameUndo{
+		state:             g.State,
+		whiteCheck:        g.WhiteCheck,
+		blackCheck:        g.BlackCheck,
+		whiteDrawAsk:      g.WhiteDrawAsk,
+		blackDrawAsk:      g.BlackDrawAsk,
+		movesSinceCapture: g.MovesSinceCapture,
+	}
+	mover := g.Board.SideToMove()
+	// do move and update board state as needed
+	if undo.board, b, r = g.Board.MakeMove(m); !b {
+		return
+	}
+	// playing on turns down the other side's draw offer
+	g.RescindDraw(mover.Opposite())
+
+	// append to movelist
+	if len(g.undos) == len(g.Moves) {
+		g.undos = append(g.undos, undo)
+	}
+	g.Moves = append(g.Moves, m)
+	g.Positions = append(g.Positions, g.Board.Position())
 
-	// update moves since capture
-	if len(g.Board.Captured) > ocl {
+	// update moves since capture or pawn move, counted in half moves like the
+	// FEN halfmove clock
+	if len(g.Board.Captured) > ocl || m.Start.Type == Pawn {
 		g.MovesSinceCapture = 0
 	} else {
 		g.MovesSinceCapture += 1
 	}
 
+	if g.updateChecks() {
+		return
+	}
+
+	// check for dead positions
+	if g.Board.InsufficientMaterial() {
+		g.State = DrawInsufficientMaterial
+		return
+	}
+
 	// check for 50 move draw
-	if g.MovesSinceCapture >= 50 {
+	if !g.Claim50Moves && g.MovesSinceCapture >= 100 {
 		g.State = Draw50Moves
+		return
 	}
 
-	// TODO: check for 3 fold repetition
-	panic("unimplemented!")
+	// check for 3 fold repetition
+	if !g.Claim3Fold && g.Repetitions() >= 3 {
+		g.State = Draw3Fold
+	}
 	return
 }
+
+// the game as it was before any of its moves were played
+func (g *Game) startGame() (Game, error) {
+	if g.StartFEN == "" {
+		return NewGame(), nil
+	}
+	return ParseFEN(g.StartFEN)
+}
+
+// Undo takes back the last move, restoring the board and game state from
+// before it; returns false if there are no moves to take back.
+func (g *Game) Undo() bool {
+	n := len(g.Moves)
+	if n == 0 {
+		return false
+	}
+	if len(g.undos) != n {
+		// the game was put together without going through DoMove, so
+		// replay it instead
+		return g.replayUndo()
+	}
+
+	u := g.undos[n-1]
+	g.Board.UnmakeMove(u.board)
+	g.State = u.state
+	g.WhiteCheck = u.whiteCheck
+	g.BlackCheck = u.blackCheck
+	g.WhiteDrawAsk = u.whiteDrawAsk
+	g.BlackDrawAsk = u.blackDrawAsk
+	g.MovesSinceCapture = u.movesSinceCapture
+	g.Moves = g.Moves[:n-1]
+	g.undos = g.undos[:n-1]
+	if len(g.Positions) > n {
+		g.Positions = g.Positions[:n]
+	}
+	return true
+}
+
+func (g *Game) replayUndo() bool {
+	ng, err := g.startGame()
+	if err != nil {
+		return false
+	}
+	ng.Claim3Fold = g.Claim3Fold
+	ng.Claim50Moves = g.Claim50Moves
+	for _, m := range g.Moves[:len(g.Moves)-1] {
+		if ok, _ := ng.DoMove(m); !ok {
+			return false
+		}
+	}
+	ng.WhiteDrawAsk = g.WhiteDrawAsk
+	ng.BlackDrawAsk = g.BlackDrawAsk
+	*g = ng
+	return true
+}
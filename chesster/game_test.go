@@ -0,0 +1,298 @@
+package chesster
+
+import (
+	"reflect"
+	"testing"
+)
+
+// shuffles both kingside knights out and back, returning to the start
+func knightShuffle() []Move {
+	return []Move{
+		Move{Start: Piece{6, 0, Knight, White, false}, End: Piece{5, 2, Knight, White, true}},
+		Move{Start: Piece{6, 7, Knight, Black, false}, End: Piece{5, 5, Knight, Black, true}},
+		Move{Start: Piece{5, 2, Knight, White, true}, End: Piece{6, 0, Knight, White, true}},
+		Move{Start: Piece{5, 5, Knight, Black, true}, End: Piece{6, 7, Knight, Black, true}},
+		Move{Start: Piece{6, 0, Knight, White, true}, End: Piece{5, 2, Knight, White, true}},
+		Move{Start: Piece{6, 7, Knight, Black, true}, End: Piece{5, 5, Knight, Black, true}},
+		Move{Start: Piece{5, 2, Knight, White, true}, End: Piece{6, 0, Knight, White, true}},
+		Move{Start: Piece{5, 5, Knight, Black, true}, End: Piece{6, 7, Knight, Black, true}},
+	}
+}
+
+func TestDraw3Fold(t *testing.T) {
+	g := NewGame()
+	ms := knightShuffle()
+	for i, m := range ms {
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move %d rejected: %d", i, r)
+		}
+		if i < len(ms)-1 && g.State != InPlay {
+			t.Fatalf("game ended early after move %d: %d", i, g.State)
+		}
+	}
+	if g.Repetitions() != 3 {
+		t.Errorf("expected %d got %d", 3, g.Repetitions())
+	}
+	if g.State != Draw3Fold {
+		t.Errorf("expected %d got %d", Draw3Fold, g.State)
+	}
+}
+
+func TestClaimDraw3Fold(t *testing.T) {
+	g := NewGame()
+	g.Claim3Fold = true
+	ms := knightShuffle()
+	for i, m := range ms[:4] {
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move %d rejected: %d", i, r)
+		}
+	}
+	if g.ClaimDraw3Fold(White) {
+		t.Errorf("claim allowed after only two repetitions")
+	}
+	for i, m := range ms[4:] {
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move %d rejected: %d", i+4, r)
+		}
+	}
+	if g.State != InPlay {
+		t.Errorf("expected %d got %d", InPlay, g.State)
+	}
+	if g.ClaimDraw3Fold(Black) {
+		t.Errorf("claim allowed for the side not on move")
+	}
+	if !g.ClaimDraw3Fold(White) {
+		t.Errorf("claim rejected after three repetitions")
+	}
+	if g.State != Draw3Fold {
+		t.Errorf("expected %d got %d", Draw3Fold, g.State)
+	}
+}
+
+func TestOfferDraw(t *testing.T) {
+	g := NewGame()
+	ms := knightShuffle()
+	g.OfferDraw(White)
+	if ok, r := g.DoMove(ms[0]); !ok {
+		t.Fatalf("move rejected: %d", r)
+	}
+	if !g.WhiteDrawAsk {
+		t.Errorf("offer withdrawn by its own side moving")
+	}
+	if ok, r := g.DoMove(ms[1]); !ok {
+		t.Fatalf("move rejected: %d", r)
+	}
+	if g.WhiteDrawAsk {
+		t.Errorf("offer still open after black played on")
+	}
+	g.Undo()
+	if !g.WhiteDrawAsk || g.State != InPlay {
+		t.Errorf("expected the offer back after undoing black's move")
+	}
+	g.OfferDraw(Black)
+	if g.State != DrawAgreed {
+		t.Errorf("expected %d got %d", DrawAgreed, g.State)
+	}
+}
+
+func TestMoveAfterEnd(t *testing.T) {
+	ms := knightShuffle()
+	end := map[string]func(g *Game){
+		"draw agreed": func(g *Game) { g.OfferDraw(White); g.OfferDraw(Black) },
+		"resigned":    func(g *Game) { g.Resign(White) },
+		"checkmate": func(g *Game) {
+			for _, s := range []string{"f2f3", "e7e5", "g2g4", "d8h4"} {
+				m, err := ParseUCI(&g.Board, s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				g.DoMove(m)
+			}
+		},
+	}
+	for name, f := range end {
+		g := NewGame()
+		f(&g)
+		state, n := g.State, len(g.Moves)
+		if ok, r := g.DoMove(ms[0]); ok || r != GameEnded {
+			t.Errorf("%s: expected %s got %s", name, GameEnded, r)
+		}
+		if g.State != state || len(g.Moves) != n || len(g.Positions) != n+1 {
+			t.Errorf("%s: game changed by a move after it ended", name)
+		}
+	}
+}
+
+func TestDraw50Moves(t *testing.T) {
+	g, err := ParseFEN("4k3/8/8/8/8/8/8/R3K3 w - - 92 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the position repeats a third time on the 100th half move as well
+	for i, s := range []string{"a1a2", "e8d8", "a2a1", "d8e8", "a1a2", "e8d8", "a2a1", "d8e8"} {
+		m, err := ParseUCI(&g.Board, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move %d rejected: %d", i, r)
+		}
+	}
+	if g.Repetitions() != 3 || g.State != Draw50Moves {
+		t.Errorf("expected %d got %d after %d repetitions", Draw50Moves, g.State, g.Repetitions())
+	}
+}
+
+func TestUndo(t *testing.T) {
+	cases := []struct {
+		fen   string
+		moves []string
+	}{
+		{StartFEN, []string{"e2e4", "d7d5", "e4d5", "c7c5", "d5c6", "g8f6", "c6b7", "e8d7", "b7a8n"}},
+		{"r3k2r/1P6/8/8/8/8/8/R3K2R w KQkq - 0 1", []string{"b7a8n", "e8g8", "e1c1", "f8a8"}},
+	}
+	for _, c := range cases {
+		g, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Board.Hash()
+		games := []Game{g.Clone()}
+		for _, s := range c.moves {
+			m, err := ParseUCI(&g.Board, s)
+			if err != nil {
+				t.Fatalf("%s: %s", s, err)
+			}
+			if ok, r := g.DoMove(m); !ok {
+				t.Fatalf("%s: move rejected: %d", s, r)
+			}
+			games = append(games, g.Clone())
+		}
+		for i := len(c.moves) - 1; i >= 0; i-- {
+			if !g.Undo() {
+				t.Fatalf("undo of %s failed", c.moves[i])
+			}
+			expected := games[i]
+			if !reflect.DeepEqual(g.Board, expected.Board) {
+				t.Errorf("undo of %s: expected %s got %s", c.moves[i], expected.ToFEN(), g.ToFEN())
+			}
+			if g.ToFEN() != expected.ToFEN() || g.State != expected.State || len(g.Positions) != len(expected.Positions) {
+				t.Errorf("undo of %s: game state differs", c.moves[i])
+			}
+		}
+		if g.Undo() {
+			t.Errorf("undo with no moves succeeded")
+		}
+	}
+}
+
+func TestUndoReplay(t *testing.T) {
+	g := NewGame()
+	ms := knightShuffle()
+	for _, m := range ms[:3] {
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move rejected: %d", r)
+		}
+	}
+	expected := g.Clone()
+	expected.Undo()
+
+	// as if the game was loaded without its undo records
+	g.undos = nil
+	if !g.Undo() {
+		t.Fatalf("undo failed")
+	}
+	if g.ToFEN() != expected.ToFEN() || len(g.Moves) != 2 {
+		t.Errorf("expected %s got %s", expected.ToFEN(), g.ToFEN())
+	}
+}
+
+func TestInsufficientMaterial(t *testing.T) {
+	cases := []struct {
+		fen      string
+		expected bool
+	}{
+		{"8/8/4k3/8/8/3K4/8/8 w - - 0 1", true},
+		{"8/8/4k3/8/8/3K4/5B2/8 w - - 0 1", true},
+		{"8/8/4k3/8/8/3K4/5N2/8 w - - 0 1", true},
+		{"8/8/4kb2/8/8/3K4/5B2/8 w - - 0 1", true},
+		{"8/8/4k1b1/8/8/3K4/5B2/8 w - - 0 1", false},
+		{"8/8/4k3/8/8/3K4/5NN1/8 w - - 0 1", false},
+		{"8/8/4k1n1/8/8/3K4/5B2/8 w - - 0 1", false},
+		{"8/8/4k3/8/8/3K4/5P2/8 w - - 0 1", false},
+		{StartFEN, false},
+	}
+	for _, c := range cases {
+		b, err := ParseBoardFEN(c.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.InsufficientMaterial() != c.expected {
+			t.Errorf("%s: expected %t got %t", c.fen, c.expected, !c.expected)
+		}
+	}
+}
+
+func TestDrawInsufficientMaterial(t *testing.T) {
+	g, err := ParseFEN("8/8/4k3/8/8/3K4/3r4/5B2 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// taking the last rook leaves king and bishop against king
+	m, err := ParseUCI(&g.Board, "d3d2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, r := g.DoMove(m); !ok {
+		t.Fatalf("move rejected: %d", r)
+	}
+	if g.State != DrawInsufficientMaterial || !g.Draw() {
+		t.Errorf("expected %d got %d", DrawInsufficientMaterial, g.State)
+	}
+	if !g.Undo() || g.State != InPlay {
+		t.Errorf("undo didn't restore the game state")
+	}
+}
+
+func TestFlagFall(t *testing.T) {
+	cases := []struct {
+		fen      string
+		side     Side
+		expected bool
+	}{
+		// black has a bare king, so white running out of time is a draw
+		{"8/8/4k3/8/8/3K4/5Q2/8 w - - 0 1", White, true},
+		{"8/8/4k3/8/8/3K4/5Q2/8 w - - 0 1", Black, false},
+		{"8/8/4k3/8/8/3K4/5N2/8 w - - 0 1", Black, true},
+		{StartFEN, White, false},
+	}
+	for _, c := range cases {
+		g, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.FlagFall(c.side) != c.expected {
+			t.Errorf("%s: expected %t got %t", c.fen, c.expected, !c.expected)
+		}
+		if c.expected && g.State != DrawInsufficientMaterial {
+			t.Errorf("%s: expected %d got %d", c.fen, DrawInsufficientMaterial, g.State)
+		}
+		if !c.expected && g.State != InPlay {
+			t.Errorf("%s: expected %d got %d", c.fen, InPlay, g.State)
+		}
+	}
+}
+
+func TestResign(t *testing.T) {
+	g := NewGame()
+	if !g.Resign(Black) || g.State != BlackResigned {
+		t.Errorf("expected %d got %d", BlackResigned, g.State)
+	}
+	if g.Resign(White) || g.State != BlackResigned {
+		t.Errorf("resigned after the game ended")
+	}
+	g = NewGame()
+	if !g.Resign(White) || g.State != WhiteResigned {
+		t.Errorf("expected %d got %d", WhiteResigned, g.State)
+	}
+}
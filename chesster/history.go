@@ -0,0 +1,141 @@
+package chesster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// what's kept of a game besides its moves, to check that replaying them
+// still gets the same game
+type Snapshot struct {
+	// empty if it wasn't recorded, in which case only State is checked
+	FEN               string
+	State             GameState
+	WhiteCheck        bool
+	BlackCheck        bool
+	MovesSinceCapture int
+}
+
+func (g *Game) Snapshot() Snapshot {
+	return Snapshot{
+		FEN:               g.ToFEN(),
+		State:             g.State,
+		WhiteCheck:        g.WhiteCheck,
+		BlackCheck:        g.BlackCheck,
+		MovesSinceCapture: g.MovesSinceCapture,
+	}
+}
+
+// one move from a stored game, in UCI notation, and the FEN of the position
+// after it if that was recorded
+type HistoryMove struct {
+	UCI string
+	FEN string
+}
+
+// one way a replayed game didn't match what was stored
+type Divergence struct {
+	// how many moves had been played; the position is the one after them,
+	// or the one the next move was played from
+	Ply      int
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("ply %d %s: expected %s, got %s", d.Ply, d.Field, d.Expected, d.Actual)
+}
+
+// the report from ReplayHistory when a game doesn't replay the way it was
+// stored
+type HistoryError struct {
+	Divergences []Divergence
+}
+
+func (e *HistoryError) Error() string {
+	ds := make([]string, len(e.Divergences))
+	for i, d := range e.Divergences {
+		ds[i] = d.String()
+	}
+	return "game history diverges: " + strings.Join(ds, "; ")
+}
+
+// ReplayHistory plays moves from start with DoMove, checking each one is
+// still legal and leads to the position recorded with it, and that the game
+// ends up matching want. If anything differs it returns a *HistoryError
+// listing where, along with the game as far as it could be replayed; it
+// stops at the first move that can't be played.
+func ReplayHistory(start Game, moves []HistoryMove, want Snapshot) (Game, error) {
+	g := start.Clone()
+	var ds []Divergence
+	diverge := func(ply int, field string, expected, actual interface{}) {
+		ds = append(ds, Divergence{ply, field, fmt.Sprint(expected), fmt.Sprint(actual)})
+	}
+
+	for i, hm := range moves {
+		if g.GameEnded() {
+			diverge(i, "move "+hm.UCI, "game in play", g.State)
+			return g, &HistoryError{ds}
+		}
+		m, err := ParseUCI(&g.Board, hm.UCI)
+		if err != nil {
+			diverge(i, "move "+hm.UCI, "legal move", err)
+			return g, &HistoryError{ds}
+		}
+		if ok, r := g.DoMove(m); !ok {
+			diverge(i, "move "+hm.UCI, "legal move", r)
+			return g, &HistoryError{ds}
+		}
+		if fen := g.ToFEN(); hm.FEN != "" && hm.FEN != fen {
+			diverge(i+1, "fen", hm.FEN, fen)
+		}
+	}
+
+	// results that don't come from a move are taken as given, if they could
+	// have happened in the position the moves lead to
+	if g.State == InPlay && g.canDeclare(want.State) {
+		g.State = want.State
+	}
+
+	got := g.Snapshot()
+	ply := len(moves)
+	if want.State != got.State {
+		diverge(ply, "state", want.State, got.State)
+	}
+	if want.FEN != "" {
+		if want.FEN != got.FEN {
+			diverge(ply, "fen", want.FEN, got.FEN)
+		}
+		if want.WhiteCheck != got.WhiteCheck {
+			diverge(ply, "white check", want.WhiteCheck, got.WhiteCheck)
+		}
+		if want.BlackCheck != got.BlackCheck {
+			diverge(ply, "black check", want.BlackCheck, got.BlackCheck)
+		}
+		if want.MovesSinceCapture != got.MovesSinceCapture {
+			diverge(ply, "moves since capture", want.MovesSinceCapture, got.MovesSinceCapture)
+		}
+	}
+	if ds != nil {
+		return g, &HistoryError{ds}
+	}
+	return g, nil
+}
+
+// whether a game in play could end in s without another move: by resigning,
+// agreeing to a draw, claiming threefold repetition or the 50-move rule, or a
+// flag falling when the other side can't mate
+func (g *Game) canDeclare(s GameState) bool {
+	switch s {
+	case WhiteResigned, BlackResigned, DrawAgreed:
+		return true
+	case Draw3Fold:
+		return g.CanClaimDraw3Fold()
+	case Draw50Moves:
+		return g.CanClaimDraw50Moves()
+	case DrawInsufficientMaterial:
+		return !g.Board.CanCheckmate(White) || !g.Board.CanCheckmate(Black)
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package chesster
+
+import (
+	"strings"
+	"testing"
+)
+
+// plays moves from start the normal way, recording them the way they'd be
+// stored
+func record(t *testing.T, start Game, ucis ...string) ([]HistoryMove, Snapshot) {
+	g := start.Clone()
+	hms := make([]HistoryMove, len(ucis))
+	for i, s := range ucis {
+		m, err := ParseUCI(&g.Board, s)
+		if err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("%s: move rejected: %s", s, r)
+		}
+		hms[i] = HistoryMove{s, g.ToFEN()}
+	}
+	return hms, g.Snapshot()
+}
+
+// checks err is a *HistoryError whose first divergence is at ply in field
+func expectDivergence(t *testing.T, err error, ply int, field string) {
+	he, ok := err.(*HistoryError)
+	if !ok {
+		t.Fatalf("expected a *HistoryError, got %v", err)
+	}
+	d := he.Divergences[0]
+	if d.Ply != ply || !strings.HasPrefix(d.Field, field) {
+		t.Errorf("expected a divergence at ply %d in %s, got %s", ply, field, d)
+	}
+}
+
+func TestReplayHistory(t *testing.T) {
+	hms, want := record(t, NewGame(), "e2e4", "e7e5", "g1f3", "b8c6", "f1b5", "a7a6", "b5c6", "d7c6")
+	g, err := ReplayHistory(NewGame(), hms, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Snapshot() != want {
+		t.Errorf("expected %+v, got %+v", want, g.Snapshot())
+	}
+
+	// positions aren't needed to check the moves
+	for i := range hms {
+		hms[i].FEN = ""
+	}
+	if _, err := ReplayHistory(NewGame(), hms, want); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReplayHistoryFEN(t *testing.T) {
+	start, err := ParseFEN("4k3/8/8/8/8/8/4P3/4K3 w - - 5 40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hms, want := record(t, start, "e2e4", "e8d7", "e1e2")
+	if _, err := ReplayHistory(start, hms, want); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReplayHistory(NewGame(), hms, want); err == nil {
+		t.Error("expected replaying from the wrong start to fail")
+	}
+}
+
+func TestReplayHistoryIllegal(t *testing.T) {
+	hms, want := record(t, NewGame(), "e2e4", "e7e5", "g1f3")
+	hms[2].UCI = "g1g3"
+	g, err := ReplayHistory(NewGame(), hms, want)
+	expectDivergence(t, err, 2, "move g1g3")
+	if len(g.Moves) != 2 {
+		t.Errorf("expected the replay to stop after 2 moves, got %d", len(g.Moves))
+	}
+
+	hms[2].UCI = "nonsense"
+	_, err = ReplayHistory(NewGame(), hms, want)
+	expectDivergence(t, err, 2, "move nonsense")
+}
+
+func TestReplayHistoryTampered(t *testing.T) {
+	hms, want := record(t, NewGame(), "e2e4", "e7e5", "g1f3", "b8c6")
+	orig := hms[1].FEN
+	hms[1].FEN = hms[3].FEN
+	_, err := ReplayHistory(NewGame(), hms, want)
+	expectDivergence(t, err, 2, "fen")
+	he := err.(*HistoryError)
+	if len(he.Divergences) != 1 {
+		t.Errorf("expected only the tampered position to diverge, got %s", he)
+	}
+	if d := he.Divergences[0]; d.Expected != hms[3].FEN || d.Actual != orig {
+		t.Errorf("expected %s vs %s, got %s", hms[3].FEN, orig, d)
+	}
+
+	want.State = WhiteCheckmate
+	want.BlackCheck = true
+	want.MovesSinceCapture = 7
+	hms[1].FEN = orig
+	_, err = ReplayHistory(NewGame(), hms, want)
+	he, ok := err.(*HistoryError)
+	if !ok {
+		t.Fatalf("expected a *HistoryError, got %v", err)
+	}
+	fields := []string{"state", "black check", "moves since capture"}
+	if len(he.Divergences) != len(fields) {
+		t.Fatalf("expected %d divergences, got %s", len(fields), he)
+	}
+	for i, f := range fields {
+		if d := he.Divergences[i]; d.Ply != 4 || d.Field != f {
+			t.Errorf("expected a divergence at ply 4 in %s, got %s", f, d)
+		}
+	}
+	if d := he.Divergences[0]; d.Expected != "WhiteCheckmate" || d.Actual != "InPlay" {
+		t.Errorf("expected WhiteCheckmate vs InPlay, got %s", d)
+	}
+}
+
+func TestReplayHistoryAfterEnd(t *testing.T) {
+	hms, want := record(t, NewGame(), "f2f3", "e7e5", "g2g4", "d8h4")
+	if want.State != BlackCheckmate || !want.WhiteCheck {
+		t.Fatalf("expected fool's mate, got %+v", want)
+	}
+	if _, err := ReplayHistory(NewGame(), hms, want); err != nil {
+		t.Fatal(err)
+	}
+	hms = append(hms, HistoryMove{UCI: "e1f2"})
+	_, err := ReplayHistory(NewGame(), hms, want)
+	expectDivergence(t, err, 4, "move e1f2")
+}
+
+func TestReplayHistoryDeclared(t *testing.T) {
+	hms, want := record(t, NewGame(), "e2e4", "e7e5")
+	for _, s := range []GameState{WhiteResigned, BlackResigned, DrawAgreed} {
+		want.State = s
+		g, err := ReplayHistory(NewGame(), hms, want)
+		if err != nil {
+			t.Errorf("%s: %s", s, err)
+		} else if g.State != s {
+			t.Errorf("expected %s, got %s", s, g.State)
+		}
+	}
+
+	// there's no repetition to claim, and both sides have plenty to mate with
+	for _, s := range []GameState{Draw3Fold, DrawInsufficientMaterial} {
+		want.State = s
+		_, err := ReplayHistory(NewGame(), hms, want)
+		expectDivergence(t, err, 2, "state")
+	}
+}
@@ -0,0 +1,64 @@
+package chesster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Perft counts the leaf nodes of the legal move tree depth half moves deep,
+// for checking move generation against published results.
+func (b *Board) Perft(depth int) uint64 {
+	if depth <= 0 {
+		return 1
+	}
+	moves := b.GetAllPossibleMoves(b.SideToMove())
+	if depth == 1 {
+		return uint64(len(moves))
+	}
+	var n uint64
+	for _, m := range moves {
+		u, ok := b.makeMove(m)
+		if !ok {
+			continue
+		}
+		n += b.Perft(depth - 1)
+		b.UnmakeMove(u)
+	}
+	return n
+}
+
+// Divide splits Perft by the first move, keyed by the move in UCI notation.
+// Comparing this against another engine narrows down which move is wrong.
+func (b *Board) Divide(depth int) map[string]uint64 {
+	d := map[string]uint64{}
+	if depth <= 0 {
+		return d
+	}
+	for _, m := range b.GetAllPossibleMoves(b.SideToMove()) {
+		u, ok := b.makeMove(m)
+		if !ok {
+			continue
+		}
+		d[m.UCI()] += b.Perft(depth - 1)
+		b.UnmakeMove(u)
+	}
+	return d
+}
+
+// formats Divide results the way most engines print them
+func FormatDivide(d map[string]uint64) string {
+	keys := make([]string, 0, len(d))
+	var total uint64
+	for k, n := range d {
+		keys = append(keys, k)
+		total += n
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s: %d\n", k, d[k])
+	}
+	fmt.Fprintf(&sb, "\nMoves: %d\nNodes: %d\n", len(keys), total)
+	return sb.String()
+}
@@ -0,0 +1,141 @@
+package chesster
+
+import (
+	"reflect"
+	"testing"
+)
+
+// positions and counts from https://www.chessprogramming.org/Perft_Results
+var perftPositions = []struct {
+	name   string
+	fen    string
+	counts []uint64
+}{
+	{"initial", StartFEN, []uint64{20, 400, 8902, 197281}},
+	{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []uint64{48, 2039, 97862, 4085603}},
+	{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []uint64{14, 191, 2812, 43238, 674624}},
+	{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []uint64{6, 264, 9467, 422333}},
+	{"position 4 mirrored", "r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1", []uint64{6, 264, 9467}},
+	{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []uint64{44, 1486, 62379}},
+	{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []uint64{46, 2079, 89890}},
+}
+
+func TestPerft(t *testing.T) {
+	for _, pos := range perftPositions {
+		b, err := ParseBoardFEN(pos.fen)
+		if err != nil {
+			t.Fatalf("%s: %s", pos.name, err)
+		}
+		for i, expected := range pos.counts {
+			depth := i + 1
+			if testing.Short() && expected > 10000 {
+				break
+			}
+			if n := b.Perft(depth); n != expected {
+				t.Errorf("%s depth %d: expected %d got %d\n%s", pos.name, depth, expected, n, FormatDivide(b.Divide(depth)))
+				break
+			}
+		}
+	}
+}
+
+func TestPerftUnmake(t *testing.T) {
+	for _, pos := range perftPositions {
+		b, err := ParseBoardFEN(pos.fen)
+		if err != nil {
+			t.Fatalf("%s: %s", pos.name, err)
+		}
+		b.Hash()
+		before := b.Clone()
+		b.Perft(3)
+		if !reflect.DeepEqual(b, before) {
+			t.Errorf("%s: board changed by make and unmake", pos.name)
+		}
+	}
+}
+
+// walks the move tree checking the bitboards kept up to date by each move
+// match ones built from scratch
+func checkCache(t *testing.T, b *Board, depth int) {
+	fresh := b.Clone()
+	fresh.rebuildCache()
+	if b.boards().bb != fresh.cache.bb || b.cache.squares != fresh.cache.squares {
+		t.Fatalf("cache out of date at %s", b.ToFEN())
+	}
+	if depth == 0 {
+		return
+	}
+	for _, m := range b.GetAllPossibleMoves(b.SideToMove()) {
+		u, ok := b.makeMove(m)
+		if !ok {
+			t.Fatalf("%s: move rejected at %s", m.UCI(), b.ToFEN())
+		}
+		checkCache(t, b, depth-1)
+		b.UnmakeMove(u)
+	}
+}
+
+func TestBoardCache(t *testing.T) {
+	for _, pos := range perftPositions {
+		b, err := ParseBoardFEN(pos.fen)
+		if err != nil {
+			t.Fatalf("%s: %s", pos.name, err)
+		}
+		checkCache(t, &b, 2)
+	}
+
+	// pieces moved by hand are picked up without being told
+	b := NewBoard()
+	b.Hash()
+	b.Pieces[12].Y = 3
+	if p := b.getPiece(4, 3); p == nil || p.Type != Pawn || b.getPiece(4, 1) != nil {
+		t.Errorf("expected the pawn on e4 got %v", p)
+	}
+	want := b.computeHash()
+	if h := b.Hash(); h != want {
+		t.Errorf("expected hash %x got %x", want, h)
+	}
+}
+
+func TestBoardEditedInPlace(t *testing.T) {
+	b := Board{}
+	b.Pieces = append(b.Pieces, Piece{4, 0, King, White, true})
+	b.Pieces = append(b.Pieces, Piece{7, 0, Rook, White, true})
+	b.Pieces = append(b.Pieces, Piece{4, 7, King, Black, true})
+	if ms := b.Pieces[1].GetPossibleMoves(&b); len(ms) != 9 {
+		t.Fatalf("expected 9 rook moves from h1 got %d", len(ms))
+	}
+
+	b.Pieces[1].X = 6
+	if b.getPiece(7, 0) != nil || b.getPiece(6, 0) == nil {
+		t.Errorf("expected the rook on g1")
+	}
+	// down the g file, and along to f1 and h1
+	if ms := b.Pieces[1].GetPossibleMoves(&b); len(ms) != 9 {
+		t.Errorf("expected 9 rook moves from g1 got %d", len(ms))
+	}
+	b.Pieces[2].X = 6
+	if !b.InCheck(Black) {
+		t.Errorf("black king on g8 not in check from the rook")
+	}
+	if ms := b.Pieces[2].GetPossibleMoves(&b); len(ms) != 4 {
+		t.Errorf("expected 4 moves off the g file got %d", len(ms))
+	}
+
+	// the same pieces in a different order
+	b.Pieces[0], b.Pieces[2] = b.Pieces[2], b.Pieces[0]
+	if p := b.getPiece(4, 0); p == nil || p.Side != White || p.Type != King {
+		t.Errorf("expected the white king on e1 got %v", p)
+	}
+	checkCache(t, &b, 1)
+}
+
+func BenchmarkPerft(b *testing.B) {
+	board, err := ParseBoardFEN(perftPositions[1].fen)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		board.Perft(3)
+	}
+}
@@ -0,0 +1,464 @@
+package chesster
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+var ErrPGNSyntax = errors.New("malformed PGN")
+
+// the Seven Tag Roster; these are always written first and in this order
+var pgnRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+// suffix annotations and the NAGs they stand for
+var pgnSuffixes = map[string]int{
+	"!":  1,
+	"?":  2,
+	"!!": 3,
+	"??": 4,
+	"!?": 5,
+	"?!": 6,
+}
+
+type PGNTag struct {
+	Name  string
+	Value string
+}
+
+// a game along with the tags and annotations needed to write it out as PGN;
+// Comments and NAGs are keyed by the number of moves in Game.Moves played
+// before them, so 0 is before the first move
+type PGN struct {
+	Tags     []PGNTag
+	Game     Game
+	Comments map[int]string
+	NAGs     map[int][]int
+}
+
+// the first illegal or unreadable move in a PGN game
+type PGNError struct {
+	// index of the game in the input, starting at 0
+	Game int
+	// half move number of the bad move, starting at 1
+	Ply    int
+	Move   string
+	Reason InvalidMoveReason
+	Err    error
+}
+
+func (e *PGNError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("game %d, ply %d: %q: %s", e.Game+1, e.Ply, e.Move, e.Err)
+	}
+	return fmt.Sprintf("game %d, ply %d: %q: invalid move (%s)", e.Game+1, e.Ply, e.Move, e.Reason)
+}
+
+// PGN result token for a game state
+func (s GameState) Result() string {
+	switch s {
+	case WhiteCheckmate, BlackResigned:
+		return "1-0"
+	case BlackCheckmate, WhiteResigned:
+		return "0-1"
+	case InPlay:
+		return "*"
+	default:
+		return "1/2-1/2"
+	}
+}
+
+// NewPGN wraps a game with an unknown Seven Tag Roster and its current result.
+func NewPGN(g Game) PGN {
+	p := PGN{
+		Tags:     []PGNTag{},
+		Game:     g,
+		Comments: map[int]string{},
+		NAGs:     map[int][]int{},
+	}
+	for _, name := range pgnRoster {
+		p.SetTag(name, "?")
+	}
+	p.SetTag("Date", "????.??.??")
+	p.SetTag("Result", g.State.Result())
+	return p
+}
+
+func (p *PGN) Tag(name string) string {
+	for _, t := range p.Tags {
+		if t.Name == name {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+func (p *PGN) SetTag(name, value string) {
+	for i, t := range p.Tags {
+		if t.Name == name {
+			p.Tags[i].Value = value
+			return
+		}
+	}
+	p.Tags = append(p.Tags, PGNTag{name, value})
+}
+
+func (p *PGN) Write(w io.Writer) error {
+	start, err := p.Game.startGame()
+	if err != nil {
+		return err
+	}
+
+	// tags; the roster goes first, followed by the rest in order
+	var sb strings.Builder
+	writeTag := func(name, value string) {
+		value = strings.Replace(value, `\`, `\\`, -1)
+		value = strings.Replace(value, `"`, `\"`, -1)
+		fmt.Fprintf(&sb, "[%s \"%s\"]\n", name, value)
+	}
+	tags := append([]PGNTag{}, p.Tags...)
+	if p.Game.StartFEN != "" {
+		tags = append(tags, PGNTag{"SetUp", "1"}, PGNTag{"FEN", p.Game.StartFEN})
+	}
+	for _, name := range pgnRoster {
+		value := p.Tag(name)
+		if value == "" {
+			value = "?"
+		}
+		writeTag(name, value)
+	}
+	written := map[string]bool{}
+	for _, name := range pgnRoster {
+		written[name] = true
+	}
+	for _, t := range tags {
+		if !written[t.Name] {
+			written[t.Name] = true
+			writeTag(t.Name, t.Value)
+		}
+	}
+	sb.WriteString("\n")
+
+	// movetext, wrapped at 80 columns
+	line := ""
+	emit := func(tok string) {
+		if line != "" && len(line)+1+len(tok) > 80 {
+			sb.WriteString(line + "\n")
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += tok
+	}
+	annotate := func(i int) bool {
+		annotated := false
+		for _, nag := range p.NAGs[i] {
+			emit("$" + strconv.Itoa(nag))
+			annotated = true
+		}
+		if c, ok := p.Comments[i]; ok {
+			emit("{" + strings.Replace(c, "}", ")", -1) + "}")
+			annotated = true
+		}
+		return annotated
+	}
+
+	b := start.Board
+	ply := start.StartPly
+	annotate(0)
+	// black's moves need their own number at the start and after annotations
+	needNumber := true
+	for i, m := range p.Game.Moves {
+		if ply%2 == 0 {
+			emit(strconv.Itoa(ply/2+1) + ".")
+		} else if needNumber {
+			emit(strconv.Itoa(ply/2+1) + "...")
+		}
+		san := m.Notation(&b)
+		if ok, r := b.TryMove(m); !ok {
+			return &PGNError{Ply: i + 1, Move: san, Reason: r}
+		}
+		emit(san)
+		needNumber = annotate(i + 1)
+		ply++
+	}
+	result := p.Tag("Result")
+	if result == "" {
+		result = p.Game.State.Result()
+	}
+	emit(result)
+	sb.WriteString(line + "\n")
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func (p *PGN) String() string {
+	var sb strings.Builder
+	if err := p.Write(&sb); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+type pgnTokenKind int
+
+const (
+	pgnTag pgnTokenKind = iota
+	pgnComment
+	pgnNAG
+	pgnMove
+	pgnResult
+)
+
+type pgnToken struct {
+	kind  pgnTokenKind
+	text  string
+	value string
+}
+
+func isPGNSymbolChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		strings.IndexByte("_+#=:-/!?.", c) != -1
+}
+
+func tokenizePGN(s string) ([]pgnToken, error) {
+	toks := []pgnToken{}
+	depth := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '%' && (i == 0 || s[i-1] == '\n'):
+			// escaped line
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case c == ';':
+			j := strings.IndexByte(s[i:], '\n')
+			if j == -1 {
+				j = len(s) - i
+			}
+			if depth == 0 {
+				toks = append(toks, pgnToken{kind: pgnComment, text: strings.TrimSpace(s[i+1 : i+j])})
+			}
+			i += j
+		case c == '{':
+			j := strings.IndexByte(s[i:], '}')
+			if j == -1 {
+				return nil, ErrPGNSyntax
+			}
+			if depth == 0 {
+				toks = append(toks, pgnToken{kind: pgnComment, text: strings.TrimSpace(s[i+1 : i+j])})
+			}
+			i += j + 1
+		case c == '(':
+			// variations aren't kept
+			depth++
+			i++
+		case c == ')':
+			if depth == 0 {
+				return nil, ErrPGNSyntax
+			}
+			depth--
+			i++
+		case c == '[':
+			j := strings.IndexByte(s[i:], ']')
+			if j == -1 {
+				return nil, ErrPGNSyntax
+			}
+			tag, err := parsePGNTag(s[i+1 : i+j])
+			if err != nil {
+				return nil, err
+			}
+			if depth == 0 {
+				toks = append(toks, tag)
+			}
+			i += j + 1
+		case c == '$':
+			j := i + 1
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			if j == i+1 {
+				return nil, ErrPGNSyntax
+			}
+			if depth == 0 {
+				toks = append(toks, pgnToken{kind: pgnNAG, text: s[i+1 : j]})
+			}
+			i = j
+		case isPGNSymbolChar(c):
+			j := i
+			for j < len(s) && isPGNSymbolChar(s[j]) {
+				j++
+			}
+			sym := s[i:j]
+			i = j
+			// a move number may run straight into the move, as in "1.e4"
+			if n := strings.TrimLeft(sym, "0123456789"); n == "" {
+				sym = ""
+			} else if len(n) < len(sym) && strings.HasPrefix(n, ".") {
+				sym = strings.TrimLeft(n, ".")
+			}
+			if sym == "" || depth > 0 {
+				continue
+			}
+			switch sym {
+			case "1-0", "0-1", "1/2-1/2":
+				toks = append(toks, pgnToken{kind: pgnResult, text: sym})
+			default:
+				toks = append(toks, pgnToken{kind: pgnMove, text: sym})
+			}
+		case c == '*':
+			if depth == 0 {
+				toks = append(toks, pgnToken{kind: pgnResult, text: "*"})
+			}
+			i++
+		case c == '.':
+			i++
+		default:
+			return nil, ErrPGNSyntax
+		}
+	}
+	if depth != 0 {
+		return nil, ErrPGNSyntax
+	}
+	return toks, nil
+}
+
+func parsePGNTag(s string) (pgnToken, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, " \t")
+	if i == -1 {
+		return pgnToken{}, ErrPGNSyntax
+	}
+	name := s[:i]
+	value := strings.TrimSpace(s[i:])
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return pgnToken{}, ErrPGNSyntax
+	}
+	value = value[1 : len(value)-1]
+	value = strings.Replace(value, `\"`, `"`, -1)
+	value = strings.Replace(value, `\\`, `\`, -1)
+	return pgnToken{kind: pgnTag, text: name, value: value}, nil
+}
+
+// ParsePGN reads every game in a PGN file, replaying the moves through
+// Game.DoMove. Threefold repetition and the 50-move rule aren't adjudicated
+// while replaying, since the players may have played on without claiming them. A game that didn't end
+// on the board stays InPlay even if its Result tag says otherwise, since the
+// PGN doesn't say how it ended; the tag is kept as it is.
+func ParsePGN(r io.Reader) ([]PGN, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	toks, err := tokenizePGN(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	games := []PGN{}
+	var cur *PGN
+	started := false
+	// starts replaying the current game once its tags are read
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		if cur.Tag("FEN") != "" {
+			g, err := ParseFEN(cur.Tag("FEN"))
+			if err != nil {
+				return &PGNError{Game: len(games), Err: err}
+			}
+			cur.Game = g
+		}
+		cur.Game.Claim3Fold = true
+		cur.Game.Claim50Moves = true
+		return nil
+	}
+	finish := func(result string) {
+		if result != "" {
+			cur.SetTag("Result", result)
+		}
+		cur.Game.Claim3Fold = false
+		cur.Game.Claim50Moves = false
+		games = append(games, *cur)
+		cur = nil
+	}
+
+	for _, tok := range toks {
+		if cur != nil && started && tok.kind == pgnTag {
+			// the previous game had no termination marker
+			finish("")
+		}
+		if cur == nil {
+			p := PGN{
+				Tags:     []PGNTag{},
+				Game:     NewGame(),
+				Comments: map[int]string{},
+				NAGs:     map[int][]int{},
+			}
+			cur = &p
+			started = false
+		}
+		switch tok.kind {
+		case pgnTag:
+			cur.SetTag(tok.text, tok.value)
+		case pgnComment:
+			if err := start(); err != nil {
+				return games, err
+			}
+			n := len(cur.Game.Moves)
+			if c, ok := cur.Comments[n]; ok {
+				cur.Comments[n] = c + " " + tok.text
+			} else {
+				cur.Comments[n] = tok.text
+			}
+		case pgnNAG:
+			if err := start(); err != nil {
+				return games, err
+			}
+			nag, _ := strconv.Atoi(tok.text)
+			n := len(cur.Game.Moves)
+			cur.NAGs[n] = append(cur.NAGs[n], nag)
+		case pgnMove:
+			if err := start(); err != nil {
+				return games, err
+			}
+			san, suffix := tok.text, ""
+			if i := strings.IndexAny(san, "!?"); i != -1 {
+				san, suffix = san[:i], san[i:]
+			}
+			ply := len(cur.Game.Moves) + 1
+			m, err := ParseSAN(&cur.Game.Board, san)
+			if err != nil {
+				return games, &PGNError{Game: len(games), Ply: ply, Move: tok.text, Err: err}
+			}
+			if ok, reason := cur.Game.DoMove(m); !ok {
+				return games, &PGNError{Game: len(games), Ply: ply, Move: tok.text, Reason: reason}
+			}
+			if nag, ok := pgnSuffixes[suffix]; ok {
+				cur.NAGs[ply] = append(cur.NAGs[ply], nag)
+			}
+		case pgnResult:
+			if err := start(); err != nil {
+				return games, err
+			}
+			finish(tok.text)
+		}
+	}
+	if cur != nil && (started || len(cur.Tags) > 0) {
+		if err := start(); err != nil {
+			return games, err
+		}
+		finish("")
+	}
+	return games, nil
+}
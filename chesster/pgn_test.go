@@ -0,0 +1,160 @@
+package chesster
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPGNWrite(t *testing.T) {
+	g := NewGame()
+	for _, san := range []string{"e4", "e5", "Nf3", "Nc6", "Bb5", "a6"} {
+		m, err := ParseSAN(&g.Board, san)
+		if err != nil {
+			t.Fatalf("%s: %s", san, err)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("%s: move rejected: %d", san, r)
+		}
+	}
+	p := NewPGN(g)
+	p.SetTag("White", "Alice")
+	p.SetTag("Black", "Bob")
+	p.SetTag("Annotator", "Carol")
+	p.Comments[0] = "Ruy Lopez"
+	p.Comments[5] = "the main line"
+	p.NAGs[6] = []int{1}
+
+	expected := `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "Alice"]
+[Black "Bob"]
+[Result "*"]
+[Annotator "Carol"]
+
+{Ruy Lopez} 1. e4 e5 2. Nf3 Nc6 3. Bb5 {the main line} 3... a6 $1 *
+`
+	if s := p.String(); s != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, s)
+	}
+}
+
+func TestPGNRoundTrip(t *testing.T) {
+	in := `[Event "Casual"]
+[Site "?"]
+[Date "2018.01.01"]
+[Round "1"]
+[White "A"]
+[Black "B"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 (2. f4 exf4) 2... Nc6 3. Bc4 Nd4?! 4. Nxe5 Qg5 5. Nxf7 Qxg2
+6. Rf1 Qxe4+ 7. Be2 Nf3# 0-1
+
+[Event "Second"]
+[Result "1-0"]
+
+1.e4 e5 {; comment} 2.Qh5 Nc6 3.Bc4 Nf6?? 1-0
+`
+	ps, err := ParsePGN(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 {
+		t.Fatalf("expected %d got %d", 2, len(ps))
+	}
+	if len(ps[0].Game.Moves) != 14 {
+		t.Errorf("expected %d got %d", 14, len(ps[0].Game.Moves))
+	}
+	if ps[0].Game.State != BlackCheckmate || ps[0].Tag("Result") != "0-1" {
+		t.Errorf("expected %d got %d, %s", BlackCheckmate, ps[0].Game.State, ps[0].Tag("Result"))
+	}
+	if nags := ps[0].NAGs[6]; len(nags) != 1 || nags[0] != 6 {
+		t.Errorf("expected [6] got %v", nags)
+	}
+	// could have been a resignation or a flag fall
+	if ps[1].Game.State != InPlay || ps[1].Tag("Result") != "1-0" {
+		t.Errorf("expected %d got %d, %s", InPlay, ps[1].Game.State, ps[1].Tag("Result"))
+	}
+	if ps[1].Comments[2] != "; comment" {
+		t.Errorf("expected %q got %q", "; comment", ps[1].Comments[2])
+	}
+
+	// writing it back out and reading it again gives the same game
+	for _, p := range ps {
+		again, err := ParsePGN(strings.NewReader(p.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(again) != 1 || again[0].String() != p.String() {
+			t.Errorf("round trip changed the game:\n%s", p.String())
+		}
+	}
+}
+
+func TestPGNFEN(t *testing.T) {
+	in := `[SetUp "1"]
+[FEN "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 30"]
+
+30. Ra8# 1-0`
+	ps, err := ParsePGN(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 1 || ps[0].Game.State != WhiteCheckmate {
+		t.Fatalf("expected a finished game, got %v", ps)
+	}
+	if !strings.Contains(ps[0].String(), "\n30. Ra8# 1-0\n") {
+		t.Errorf("bad movetext:\n%s", ps[0].String())
+	}
+}
+
+func TestPGNIllegalMove(t *testing.T) {
+	in := `1. e4 e5 2. Nf3 Ke7 3. Ke3 *`
+	_, err := ParsePGN(strings.NewReader(in))
+	perr, ok := err.(*PGNError)
+	if !ok {
+		t.Fatalf("expected a PGNError, got %v", err)
+	}
+	if perr.Ply != 5 || perr.Move != "Ke3" {
+		t.Errorf("expected ply %d %s got %d %s", 5, "Ke3", perr.Ply, perr.Move)
+	}
+}
+
+func TestPGN50Moves(t *testing.T) {
+	in := `[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/8/R3K3 w - - 98 60"]
+
+60. Ra2 Kd8 61. Ra3 Ke8 *`
+	ps, err := ParsePGN(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &ps[0].Game
+	if g.State != InPlay || len(g.Moves) != 4 || !g.CanClaimDraw50Moves() {
+		t.Fatalf("expected the game still in play after 4 moves, got %s after %d", g.State, len(g.Moves))
+	}
+	if g.Claim50Moves {
+		t.Errorf("imported game left needing the 50-move rule claimed")
+	}
+	if !g.ClaimDraw50Moves(White) || g.State != Draw50Moves {
+		t.Errorf("expected a claimed draw got %s", g.State)
+	}
+}
+
+func TestPGNErrorReason(t *testing.T) {
+	// white's capture leaves bare kings, so black can't reply
+	in := `[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/3p4/4K3 w - - 0 1"]
+
+1. Kxd2 Ke7 *`
+	_, err := ParsePGN(strings.NewReader(in))
+	perr, ok := err.(*PGNError)
+	if !ok || perr.Reason != GameEnded {
+		t.Fatalf("expected a PGNError with %s, got %v", GameEnded, err)
+	}
+	if !strings.Contains(err.Error(), "GameEnded") {
+		t.Errorf("reason not named in %q", err)
+	}
+}
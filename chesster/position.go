@@ -0,0 +1,83 @@
+package chesster
+
+type square struct {
+	Type PieceType
+	Side Side
+}
+
+// Position identifies a board configuration for repetition purposes. Two
+// boards have equal positions if the same pieces are on the same squares, the
+// same side is to move, and both sides have the same castling and en passant
+// options. Positions are comparable, so they can be used as map keys.
+type Position struct {
+	Squares [8][8]square
+	State   BoardState
+	// castling rights; kingside then queenside
+	WhiteCastle [2]bool
+	BlackCastle [2]bool
+	// file of a pawn that can be captured en passant, -1 if none
+	EnPassant int
+}
+
+func (b *Board) canCastle(s Side, kingside bool) bool {
+	var y int
+	if s == White {
+		y = 0
+	} else {
+		y = 7
+	}
+	k := b.getPiece(4, y)
+	if k == nil || k.Type != King || k.Side != s || k.HasMoved {
+		return false
+	}
+	var r *Piece
+	if kingside {
+		r = b.getPiece(7, y)
+	} else {
+		r = b.getPiece(0, y)
+	}
+	return r != nil && r.Type == Rook && r.Side == s && !r.HasMoved
+}
+
+// returns the en passant file only if a pawn of the side to move can actually
+// make the capture; otherwise the positions are the same as far as FIDE is
+// concerned
+func (b *Board) enPassantFile() int {
+	var file, y int
+	var s Side
+	if b.State == WhiteMove {
+		file, y, s = b.BlackEnPassant, 4, White
+	} else {
+		file, y, s = b.WhiteEnPassant, 3, Black
+	}
+	if file == -1 {
+		return -1
+	}
+	for _, x := range []int{file - 1, file + 1} {
+		if p := b.getPiece(x, y); p != nil && p.Type == Pawn && p.Side == s {
+			return file
+		}
+	}
+	return -1
+}
+
+func (b *Board) Position() Position {
+	pos := Position{
+		State: b.State,
+		WhiteCastle: [2]bool{
+			b.canCastle(White, true),
+			b.canCastle(White, false),
+		},
+		BlackCastle: [2]bool{
+			b.canCastle(Black, true),
+			b.canCastle(Black, false),
+		},
+		EnPassant: b.enPassantFile(),
+	}
+	for _, p := range b.Pieces {
+		if isInBounds(p.X, p.Y) {
+			pos.Squares[p.X][p.Y] = square{p.Type, p.Side}
+		}
+	}
+	return pos
+}
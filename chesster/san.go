@@ -0,0 +1,241 @@
+package chesster
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrSANSyntax    = errors.New("malformed SAN move")
+	ErrSANNoMove    = errors.New("SAN move doesn't match any legal move")
+	ErrSANAmbiguous = errors.New("SAN move matches more than one legal move")
+)
+
+var sanLetters = map[PieceType]byte{
+	Rook:   'R',
+	Knight: 'N',
+	Bishop: 'B',
+	King:   'K',
+	Queen:  'Q',
+}
+
+func sanPiece(c byte) PieceType {
+	for t, l := range sanLetters {
+		if l == c {
+			return t
+		}
+	}
+	return InvalidPiece
+}
+
+func fileName(x int) string {
+	if x < 0 || x > 7 {
+		return "?"
+	}
+	return string(rune('a' + x))
+}
+
+func rankName(y int) string {
+	if y < 0 || y > 7 {
+		return "?"
+	}
+	return string(rune('1' + y))
+}
+
+func squareName(x, y int) string {
+	return fileName(x) + rankName(y)
+}
+
+// Notation returns the move in Standard Algebraic Notation; b is the board
+// before the move is made
+func (m Move) Notation(b *Board) string {
+	return m.notation(b, false)
+}
+
+// like Notation, but marks en passant captures with "e.p."
+func (m Move) NotationEP(b *Board) string {
+	return m.notation(b, true)
+}
+
+func (m Move) notation(b *Board, ep bool) string {
+	s := ""
+	if m.IsCastle {
+		if m.IsKingsideCastle {
+			s = "O-O"
+		} else {
+			s = "O-O-O"
+		}
+		return s + m.checkSuffix(b)
+	}
+
+	switch m.Start.Type {
+	case Pawn:
+		// pawn captures are named by the file they leave from
+		if m.Capture {
+			s += fileName(m.Start.X)
+		}
+	case Rook, Knight, Bishop, King, Queen:
+		s += string(sanLetters[m.Start.Type])
+		s += m.disambiguation(b)
+	default:
+		s += "?"
+	}
+	if m.Capture {
+		s += "x"
+	}
+	s += squareName(m.End.X, m.End.Y)
+	if m.IsPromotion {
+		if l, ok := sanLetters[m.End.Type]; ok {
+			s += "=" + string(l)
+		} else {
+			s += "=?"
+		}
+	}
+	if ep && m.isEnPassant(b) {
+		s += " e.p."
+	}
+	return s + m.checkSuffix(b)
+}
+
+func (m Move) isEnPassant(b *Board) bool {
+	return m.Start.Type == Pawn && m.Capture && m.Start.X != m.End.X && b.getPiece(m.End.X, m.End.Y) == nil
+}
+
+// the shortest prefix needed to tell this move apart from other moves by the
+// same kind of piece to the same square
+func (m Move) disambiguation(b *Board) string {
+	sameFile, sameRank, others := false, false, false
+	for _, p := range b.Pieces {
+		if p.Side != m.Start.Side || p.Type != m.Start.Type || (p.X == m.Start.X && p.Y == m.Start.Y) {
+			continue
+		}
+		for _, o := range p.GetPossibleMoves(b) {
+			if o.IsCastle || o.End.X != m.End.X || o.End.Y != m.End.Y {
+				continue
+			}
+			others = true
+			if p.X == m.Start.X {
+				sameFile = true
+			}
+			if p.Y == m.Start.Y {
+				sameRank = true
+			}
+			break
+		}
+	}
+	if !others {
+		return ""
+	}
+	if !sameFile {
+		return fileName(m.Start.X)
+	}
+	if !sameRank {
+		return rankName(m.Start.Y)
+	}
+	return squareName(m.Start.X, m.Start.Y)
+}
+
+func (m Move) checkSuffix(b *Board) string {
+	nb := b.Clone()
+	if !nb.commitMove(m) {
+		return ""
+	}
+	opp := m.Start.Side.Opposite()
+	if !nb.InCheck(opp) {
+		return ""
+	}
+	if nb.noMoves(opp) {
+		return "#"
+	}
+	return "+"
+}
+
+// ParseSAN finds the legal move for the side to move on b that matches a move
+// written in Standard Algebraic Notation. Check, mate and annotation symbols
+// are ignored, as is a missing capture mark.
+func ParseSAN(b *Board, san string) (Move, error) {
+	s := strings.TrimSpace(san)
+	s = strings.TrimSpace(strings.Replace(s, "e.p.", "", -1))
+	s = strings.TrimRight(s, "+#!?")
+
+	var matches []Move
+	moves := b.GetAllPossibleMoves(b.SideToMove())
+
+	switch s {
+	case "O-O", "0-0", "O-O-O", "0-0-0":
+		kingside := len(s) == 3
+		for _, m := range moves {
+			if m.IsCastle && m.IsKingsideCastle == kingside {
+				matches = append(matches, m)
+			}
+		}
+		return uniqueMove(matches)
+	}
+
+	pieceType := Pawn
+	if len(s) > 0 && sanPiece(s[0]) != InvalidPiece {
+		pieceType = sanPiece(s[0])
+		s = s[1:]
+	}
+
+	promotion := InvalidPiece
+	if n := len(s); n > 0 && sanPiece(s[n-1]) != InvalidPiece {
+		promotion = sanPiece(s[n-1])
+		s = strings.TrimSuffix(s[:n-1], "=")
+		if pieceType != Pawn || promotion == King {
+			return Move{}, ErrSANSyntax
+		}
+	}
+
+	// what's left is [file][rank][x]square
+	if len(s) < 2 {
+		return Move{}, ErrSANSyntax
+	}
+	ex, ey := int(s[len(s)-2]-'a'), int(s[len(s)-1]-'1')
+	if !isInBounds(ex, ey) {
+		return Move{}, ErrSANSyntax
+	}
+	s = s[:len(s)-2]
+	capture := strings.HasSuffix(s, "x")
+	s = strings.TrimSuffix(s, "x")
+	fromX, fromY := -1, -1
+	if len(s) > 0 && s[0] >= 'a' && s[0] <= 'h' {
+		fromX = int(s[0] - 'a')
+		s = s[1:]
+	}
+	if len(s) > 0 && s[0] >= '1' && s[0] <= '8' {
+		fromY = int(s[0] - '1')
+		s = s[1:]
+	}
+	if len(s) > 0 {
+		return Move{}, ErrSANSyntax
+	}
+
+	for _, m := range moves {
+		if m.IsCastle || m.Start.Type != pieceType || m.End.X != ex || m.End.Y != ey {
+			continue
+		}
+		if (fromX != -1 && m.Start.X != fromX) || (fromY != -1 && m.Start.Y != fromY) {
+			continue
+		}
+		if capture && !m.Capture {
+			continue
+		}
+		if m.IsPromotion != (promotion != InvalidPiece) || (m.IsPromotion && m.End.Type != promotion) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return uniqueMove(matches)
+}
+
+func uniqueMove(matches []Move) (Move, error) {
+	switch len(matches) {
+	case 0:
+		return Move{}, ErrSANNoMove
+	case 1:
+		return matches[0], nil
+	default:
+		return Move{}, ErrSANAmbiguous
+	}
+}
@@ -0,0 +1,86 @@
+package chesster
+
+import (
+	"testing"
+)
+
+func TestSANRoundTrip(t *testing.T) {
+	cases := []struct {
+		fen string
+		san string
+	}{
+		{StartFEN, "Nf3"},
+		{StartFEN, "e4"},
+		{"4k3/8/8/8/8/8/8/R4RK1 w - - 0 1", "Rad1"},
+		{"4k3/8/8/8/8/8/8/R4RK1 w - - 0 1", "Rfd1"},
+		{"4k3/8/8/R7/8/8/8/R5K1 w - - 0 1", "R1a3"},
+		{"4k3/8/8/R7/8/8/8/R5K1 w - - 0 1", "R5a3"},
+		{"4k3/8/8/8/8/Q7/8/Q1Q3K1 w - - 0 1", "Qa1b2"},
+		{"3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1", "exd8=N+"},
+		{"3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1", "e8=R"},
+		{"3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1", "e8=Q+"},
+		{"6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", "Ra8#"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "O-O"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "O-O-O"},
+	}
+	for _, c := range cases {
+		g, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Fatalf("%s: %s", c.fen, err)
+		}
+		m, err := ParseSAN(&g.Board, c.san)
+		if err != nil {
+			t.Errorf("%s %s: %s", c.fen, c.san, err)
+			continue
+		}
+		if s := m.Notation(&g.Board); s != c.san {
+			t.Errorf("%s: expected %s got %s", c.fen, c.san, s)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Errorf("%s %s: move rejected: %d", c.fen, c.san, r)
+		}
+	}
+}
+
+func TestSANLenient(t *testing.T) {
+	g, err := ParseFEN("3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, san := range []string{"exd8N", "ed8=N", "exd8=N!?", "e7d8=N"} {
+		m, err := ParseSAN(&g.Board, san)
+		if err != nil {
+			t.Errorf("%s: %s", san, err)
+			continue
+		}
+		if m.End.Type != Knight || m.End.X != 3 || m.End.Y != 7 {
+			t.Errorf("%s: wrong move %v", san, m)
+		}
+	}
+}
+
+func TestSANInvalid(t *testing.T) {
+	cases := []struct {
+		fen string
+		san string
+		err error
+	}{
+		{"4k3/8/8/8/8/8/8/R4RK1 w - - 0 1", "Rd1", ErrSANAmbiguous},
+		{"4k3/8/8/8/8/8/8/R4RK1 w - - 0 1", "Ke3", ErrSANNoMove},
+		{"4k3/8/8/8/8/8/8/R4RK1 w - - 0 1", "Rxd1", ErrSANNoMove},
+		{"4k3/8/8/8/8/8/8/R4RK1 w - - 0 1", "O-O", ErrSANNoMove},
+		{"3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1", "e8", ErrSANNoMove},
+		{StartFEN, "Zz9", ErrSANSyntax},
+		{StartFEN, "", ErrSANSyntax},
+		{StartFEN, "e8=K", ErrSANSyntax},
+	}
+	for _, c := range cases {
+		b, err := ParseBoardFEN(c.fen)
+		if err != nil {
+			t.Fatalf("%s: %s", c.fen, err)
+		}
+		if _, err := ParseSAN(&b, c.san); err != c.err {
+			t.Errorf("%s %q: expected %v got %v", c.fen, c.san, c.err, err)
+		}
+	}
+}
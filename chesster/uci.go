@@ -0,0 +1,89 @@
+package chesster
+
+import (
+	"errors"
+)
+
+var (
+	ErrUCISyntax = errors.New("malformed UCI move")
+	ErrUCINoMove = errors.New("UCI move doesn't match any legal move")
+)
+
+// UCI returns the move in the long algebraic notation used by UCI engines,
+// like "e2e4" or "e7e8q". Castling is written as the king's move.
+func (m Move) UCI() string {
+	sx, sy, ex, ey := m.Start.X, m.Start.Y, m.End.X, m.End.Y
+	if m.IsCastle {
+		sy = 0
+		if m.Start.Side == Black {
+			sy = 7
+		}
+		sx, ey, ex = 4, sy, 2
+		if m.IsKingsideCastle {
+			ex = 6
+		}
+	}
+	s := squareName(sx, sy) + squareName(ex, ey)
+	if m.IsPromotion {
+		if l, ok := fenLetters[m.End.Type]; ok {
+			s += string(l)
+		}
+	}
+	return s
+}
+
+func parseSquare(s string) (int, int, bool) {
+	if len(s) != 2 {
+		return 0, 0, false
+	}
+	x, y := int(s[0])-'a', int(s[1])-'1'
+	return x, y, isInBounds(x, y)
+}
+
+// ParseUCI finds the legal move for the side to move on b that matches a move
+// in UCI long algebraic notation. Castling may be given either as the king's
+// move or as the king capturing its own rook.
+func ParseUCI(b *Board, s string) (Move, error) {
+	if len(s) != 4 && len(s) != 5 {
+		return Move{}, ErrUCISyntax
+	}
+	sx, sy, ok := parseSquare(s[0:2])
+	if !ok {
+		return Move{}, ErrUCISyntax
+	}
+	ex, ey, ok := parseSquare(s[2:4])
+	if !ok {
+		return Move{}, ErrUCISyntax
+	}
+	promotion := InvalidPiece
+	if len(s) == 5 {
+		t, _, ok := fenPiece(s[4])
+		if !ok || t == Pawn || t == King {
+			return Move{}, ErrUCISyntax
+		}
+		promotion = t
+	}
+
+	p := b.getPiece(sx, sy)
+	if p == nil || p.Side != b.SideToMove() {
+		return Move{}, ErrUCINoMove
+	}
+	// king takes own rook castling
+	if r := b.getPiece(ex, ey); p.Type == King && r != nil && r.Type == Rook && r.Side == p.Side {
+		if ex == 7 {
+			ex = 6
+		} else if ex == 0 {
+			ex = 2
+		}
+	}
+	for _, m := range p.GetPossibleMoves(b) {
+		if m.End.X != ex || m.End.Y != ey {
+			continue
+		}
+		if m.IsPromotion != (promotion != InvalidPiece) || (m.IsPromotion && m.End.Type != promotion) {
+			continue
+		}
+		return m, nil
+	}
+	return Move{}, ErrUCINoMove
+}
@@ -0,0 +1,76 @@
+package chesster
+
+import (
+	"testing"
+)
+
+func TestUCIRoundTrip(t *testing.T) {
+	cases := []struct {
+		fen string
+		uci string
+		san string
+	}{
+		{StartFEN, "e2e4", "e4"},
+		{StartFEN, "g1f3", "Nf3"},
+		{"3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1", "e7d8n", "exd8=N+"},
+		{"3r4/4Pk2/8/8/8/8/8/4K3 w - - 0 1", "e7e8r", "e8=R"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1g1", "O-O"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8c8", "O-O-O"},
+	}
+	for _, c := range cases {
+		g, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Fatalf("%s: %s", c.fen, err)
+		}
+		m, err := ParseUCI(&g.Board, c.uci)
+		if err != nil {
+			t.Errorf("%s %s: %s", c.fen, c.uci, err)
+			continue
+		}
+		if s := m.Notation(&g.Board); s != c.san {
+			t.Errorf("%s %s: expected %s got %s", c.fen, c.uci, c.san, s)
+		}
+		if s := m.UCI(); s != c.uci {
+			t.Errorf("%s: expected %s got %s", c.fen, c.uci, s)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Errorf("%s %s: move rejected: %d", c.fen, c.uci, r)
+		}
+	}
+}
+
+func TestUCIKingTakesRook(t *testing.T) {
+	b, err := ParseBoardFEN("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := ParseUCI(&b, "e1h1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsCastle || !m.IsKingsideCastle {
+		t.Errorf("expected kingside castle, got %v", m)
+	}
+}
+
+func TestUCIInvalid(t *testing.T) {
+	cases := []struct {
+		uci string
+		err error
+	}{
+		{"", ErrUCISyntax},
+		{"e2e", ErrUCISyntax},
+		{"e2e9", ErrUCISyntax},
+		{"e2e4k", ErrUCISyntax},
+		{"e2e5", ErrUCINoMove},
+		{"e7e5", ErrUCINoMove},
+		{"e3e4", ErrUCINoMove},
+		{"e2e4q", ErrUCINoMove},
+	}
+	b := NewBoard()
+	for _, c := range cases {
+		if _, err := ParseUCI(&b, c.uci); err != c.err {
+			t.Errorf("%q: expected %v got %v", c.uci, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package chesster
+
+// Zobrist keys; generated from a fixed seed so hashes stay the same between
+// runs and can be stored
+var (
+	zobristPieces    [2][7][64]uint64
+	zobristBlackMove uint64
+	// white kingside, white queenside, black kingside, black queenside
+	zobristCastle    [4]uint64
+	zobristEnPassant [8]uint64
+)
+
+func init() {
+	// splitmix64
+	seed := uint64(0x63686573737465)
+	next := func() uint64 {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+	for s := range zobristPieces {
+		for t := range zobristPieces[s] {
+			for i := range zobristPieces[s][t] {
+				zobristPieces[s][t][i] = next()
+			}
+		}
+	}
+	zobristBlackMove = next()
+	for i := range zobristCastle {
+		zobristCastle[i] = next()
+	}
+	for i := range zobristEnPassant {
+		zobristEnPassant[i] = next()
+	}
+}
+
+func pieceKey(p Piece) uint64 {
+	if !isInBounds(p.X, p.Y) || p.Type < InvalidPiece || p.Type > Queen || (p.Side != White && p.Side != Black) {
+		return 0
+	}
+	return zobristPieces[p.Side][p.Type][p.X*8+p.Y]
+}
+
+// key for everything but the piece placement
+func (b *Board) stateKey() uint64 {
+	var h uint64
+	if b.State == BlackMove {
+		h ^= zobristBlackMove
+	}
+	if b.canCastle(White, true) {
+		h ^= zobristCastle[0]
+	}
+	if b.canCastle(White, false) {
+		h ^= zobristCastle[1]
+	}
+	if b.canCastle(Black, true) {
+		h ^= zobristCastle[2]
+	}
+	if b.canCastle(Black, false) {
+		h ^= zobristCastle[3]
+	}
+	if f := b.enPassantFile(); f != -1 {
+		h ^= zobristEnPassant[f]
+	}
+	return h
+}
+
+func (b *Board) computeHash() uint64 {
+	h := b.stateKey()
+	for _, p := range b.Pieces {
+		h ^= pieceKey(p)
+	}
+	return h
+}
+
+// applies the piece key changes from a move along with the new state key
+func (b *Board) updateHash(delta uint64) {
+	if b.hashValid {
+		b.hash ^= delta ^ b.stateKey()
+	}
+}
+
+// Zobrist hash of the position; boards with equal Positions have equal hashes.
+// The hash is computed on first use and then kept up to date by every move, as
+// are the bitboards used to find moves; both are worked out again if Pieces or
+// State were changed by hand since.
+func (b *Board) Hash() uint64 {
+	b.boards()
+	if !b.hashValid {
+		b.Rehash()
+	}
+	return b.hash
+}
+
+func (b *Board) Rehash() {
+	b.rebuildCache()
+	b.hash = b.computeHash()
+	b.hashValid = true
+}
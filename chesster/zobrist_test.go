@@ -0,0 +1,50 @@
+package chesster
+
+import (
+	"testing"
+)
+
+func TestHashIncremental(t *testing.T) {
+	g := NewGame()
+	g.Board.Hash()
+	for i, m := range knightShuffle()[:4] {
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("move %d rejected: %d", i, r)
+		}
+		if g.Board.Hash() != g.Board.computeHash() {
+			t.Errorf("move %d: incremental hash %x, expected %x", i, g.Board.Hash(), g.Board.computeHash())
+		}
+	}
+	b := NewBoard()
+	if g.Board.Hash() != b.Hash() {
+		t.Errorf("expected %x got %x", b.Hash(), g.Board.Hash())
+	}
+}
+
+func TestHashTransposition(t *testing.T) {
+	nf3 := Move{Start: Piece{6, 0, Knight, White, false}, End: Piece{5, 2, Knight, White, true}}
+	nc3 := Move{Start: Piece{1, 0, Knight, White, false}, End: Piece{2, 2, Knight, White, true}}
+	nf6 := Move{Start: Piece{6, 7, Knight, Black, false}, End: Piece{5, 5, Knight, Black, true}}
+
+	a := NewBoard()
+	a.Hash()
+	b := NewBoard()
+	b.Hash()
+	for _, m := range []Move{nf3, nf6, nc3} {
+		if ok, r := a.TryMove(m); !ok {
+			t.Fatalf("move rejected: %d", r)
+		}
+	}
+	for _, m := range []Move{nc3, nf6, nf3} {
+		if ok, r := b.TryMove(m); !ok {
+			t.Fatalf("move rejected: %d", r)
+		}
+	}
+	if a.Hash() != b.Hash() {
+		t.Errorf("transposed positions hash differently: %x %x", a.Hash(), b.Hash())
+	}
+	c := NewBoard()
+	if a.Hash() == c.Hash() {
+		t.Errorf("different positions hash the same")
+	}
+}
@@ -1,13 +1,62 @@
 package main
 
 import (
-	api "github.com/cactorium/chesster-server/api"
-	chesster "github.com/cactorium/chesster-server/chesster"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	auth "github.com/cactorium/chesster-server/auth"
+	games "github.com/cactorium/chesster-server/games"
+	server "github.com/cactorium/chesster-server/server"
+	storage "github.com/cactorium/chesster-server/storage"
 )
 
+const defaultDB = "chesster.db"
+
 func main() {
-	test := chesster.Piece{}
-	move := api.Move{}
-	_ = test
-	_ = move
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrateMain(os.Args[2:])
+		return
+	}
+
+	addr := flag.String("addr", server.DefaultAddr, "address to listen on")
+	db := flag.String("db", defaultDB, "SQLite database to keep everything in")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		s := <-sigs
+		log.Printf("got %s, shutting down", s)
+		cancel()
+	}()
+
+	store, err := storage.Open(*db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer store.Close()
+	h, err := auth.NewHandler(store)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := h.LoadSecret(store); err != nil {
+		log.Fatal(err)
+	}
+	h.DeviceKeys = store
+	h.Sessions.Store = store
+	if err := h.Sessions.Load(); err != nil {
+		log.Fatal(err)
+	}
+	go h.Sessions.Run(ctx)
+	srv := &server.Server{Addr: *addr, Auth: h, Game: games.NewHandler(store, store), Middleware: h}
+	log.Printf("listening on %s", *addr)
+	if err := srv.ListenAndServe(ctx); err != server.ErrServerClosed {
+		log.Fatal(err)
+	}
 }
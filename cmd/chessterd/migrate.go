@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	storage "github.com/cactorium/chesster-server/storage"
+)
+
+const migrateUsage = `usage: chessterd migrate [-db path] [status|up|dry-run]
+
+  status   show the database's schema version and the migrations it needs
+  up       apply the migrations it needs
+  dry-run  check the migrations it needs apply cleanly, without keeping them
+`
+
+// chessterd migrate; status if there's no mode given
+func migrateMain(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		fs.PrintDefaults()
+	}
+	db := fs.String("db", defaultDB, "SQLite database to migrate")
+	fs.Parse(args)
+	mode := "status"
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		mode = fs.Arg(0)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	m, err := storage.NewMigrator(*db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer m.Close()
+	v, err := m.Version()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch mode {
+	case "status":
+		fmt.Printf("schema version %d, latest %d\n", v, m.Latest())
+		pending, err := m.Pending()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, mg := range pending {
+			fmt.Printf("pending %s\n", mg)
+		}
+	case "up":
+		applied, err := m.Up()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, mg := range applied {
+			fmt.Printf("applied %s\n", mg)
+		}
+		fmt.Printf("schema version %d\n", v+len(applied))
+	case "dry-run":
+		pending, err := m.DryRun()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, mg := range pending {
+			fmt.Printf("would apply %s\n", mg)
+		}
+		fmt.Printf("schema version %d, would be %d; nothing was changed\n", v, v+len(pending))
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+}
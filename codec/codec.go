@@ -0,0 +1,154 @@
+// Package codec frames api.Message values on a stream, each one written as
+// its length followed by the marshalled message. It's shared by the server
+// and its clients.
+package codec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	proto "github.com/golang/protobuf/proto"
+
+	api "github.com/cactorium/chesster-server/api"
+)
+
+// how the length of each frame is written
+type Prefix int
+
+const (
+	// four bytes, big endian
+	Uint32 Prefix = iota
+	// protobuf style unsigned varint
+	Varint
+)
+
+const DefaultMaxFrameSize = 1 << 20
+
+var (
+	// the stream is out of sync after ErrFrameSize or ErrTruncated, so
+	// there's nothing left to do but close it
+	ErrFrameSize = errors.New("frame larger than the maximum size")
+	ErrTruncated = errors.New("stream ended partway through a frame")
+	// the frame was read but isn't an api.Message; the next one can still be
+	// read
+	ErrMalformed = errors.New("frame isn't a valid message")
+)
+
+// the InvalidRequest a peer should get for a codec error; nil if err didn't
+// come from a bad frame
+func Invalid(err error) *api.InvalidRequest {
+	if err == ErrFrameSize || err == ErrTruncated || err == ErrMalformed {
+		return &api.InvalidRequest{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: err.Error()}
+	}
+	return nil
+}
+
+type Codec struct {
+	Prefix Prefix
+	// larger frames are rejected both ways; DefaultMaxFrameSize if 0
+	MaxFrameSize int
+
+	r   *bufio.Reader
+	w   io.Writer
+	wmu sync.Mutex
+}
+
+// reads and writes frames on rw. Reads are buffered, so nothing else should
+// read from rw afterwards
+func New(rw io.ReadWriter, p Prefix) *Codec {
+	return &Codec{
+		Prefix: p,
+		r:      bufio.NewReader(rw),
+		w:      rw,
+	}
+}
+
+func (c *Codec) maxFrameSize() uint64 {
+	if c.MaxFrameSize <= 0 {
+		return DefaultMaxFrameSize
+	}
+	return uint64(c.MaxFrameSize)
+}
+
+// reads the next frame; io.EOF if the stream ended cleanly between frames
+func (c *Codec) ReadFrame() ([]byte, error) {
+	var n uint64
+	switch c.Prefix {
+	case Varint:
+		var err error
+		n, err = binary.ReadUvarint(c.r)
+		if err == io.ErrUnexpectedEOF {
+			return nil, ErrTruncated
+		} else if err != nil && err != io.EOF {
+			// too many bytes for a uint64
+			return nil, ErrFrameSize
+		} else if err != nil {
+			return nil, err
+		}
+	default:
+		var l [4]byte
+		if _, err := io.ReadFull(c.r, l[:]); err == io.ErrUnexpectedEOF {
+			return nil, ErrTruncated
+		} else if err != nil {
+			return nil, err
+		}
+		n = uint64(binary.BigEndian.Uint32(l[:]))
+	}
+
+	if n > c.maxFrameSize() {
+		return nil, ErrFrameSize
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(c.r, buf); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, ErrTruncated
+	} else if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// reads the next message
+func (c *Codec) Read() (*api.Message, error) {
+	buf, err := c.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+	m := &api.Message{}
+	if err := proto.Unmarshal(buf, m); err != nil {
+		return nil, ErrMalformed
+	}
+	return m, nil
+}
+
+// writes buf as one frame; safe to call from more than one goroutine
+func (c *Codec) WriteFrame(buf []byte) error {
+	if uint64(len(buf)) > c.maxFrameSize() {
+		return ErrFrameSize
+	}
+	var frame []byte
+	switch c.Prefix {
+	case Varint:
+		frame = make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(buf))
+		frame = frame[:binary.PutUvarint(frame, uint64(len(buf)))]
+	default:
+		frame = make([]byte, 4, 4+len(buf))
+		binary.BigEndian.PutUint32(frame, uint32(len(buf)))
+	}
+	frame = append(frame, buf...)
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := c.w.Write(frame)
+	return err
+}
+
+func (c *Codec) Write(m *api.Message) error {
+	buf, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return c.WriteFrame(buf)
+}
@@ -0,0 +1,93 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	proto "github.com/golang/protobuf/proto"
+
+	api "github.com/cactorium/chesster-server/api"
+)
+
+func testMessage(id uint32) *api.Message {
+	return &api.Message{
+		Header:  &api.Header{ReqId: id, Token: []byte("token")},
+		Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_ListKeys{ListKeys: &api.ListKeys{}}}},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, p := range []Prefix{Uint32, Varint} {
+		var buf bytes.Buffer
+		c := New(&buf, p)
+		for i := uint32(0); i < 3; i++ {
+			if err := c.Write(testMessage(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i := uint32(0); i < 3; i++ {
+			m, err := c.Read()
+			if err != nil {
+				t.Fatalf("prefix %d: %s", p, err)
+			}
+			if !proto.Equal(m, testMessage(i)) {
+				t.Errorf("prefix %d: expected %v got %v", p, testMessage(i), m)
+			}
+		}
+		if _, err := c.Read(); err != io.EOF {
+			t.Errorf("prefix %d: expected %v got %v", p, io.EOF, err)
+		}
+	}
+}
+
+func TestBadFrames(t *testing.T) {
+	cases := []struct {
+		p     Prefix
+		frame []byte
+		err   error
+	}{
+		{Uint32, []byte{0, 0}, ErrTruncated},
+		{Uint32, []byte{0, 0, 0, 4, 1, 2}, ErrTruncated},
+		{Uint32, []byte{0, 0x10, 0, 1}, ErrFrameSize},
+		{Uint32, []byte{0, 0, 0, 2, 0xff, 0xff}, ErrMalformed},
+		{Varint, []byte{0x80}, ErrTruncated},
+		{Varint, []byte{4, 1, 2}, ErrTruncated},
+		{Varint, []byte{0x81, 0x80, 0x40}, ErrFrameSize},
+		{Varint, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, ErrFrameSize},
+		{Varint, []byte{2, 0xff, 0xff}, ErrMalformed},
+	}
+	for i, c := range cases {
+		_, err := New(bytes.NewBuffer(c.frame), c.p).Read()
+		if err != c.err {
+			t.Errorf("case %d: expected %v got %v", i, c.err, err)
+		}
+		if ir := Invalid(err); ir == nil || ir.Code != api.InvalidRequest_MALFORMED_REQUEST {
+			t.Errorf("case %d: expected %s got %v", i, api.InvalidRequest_MALFORMED_REQUEST, ir)
+		}
+	}
+	if Invalid(io.EOF) != nil {
+		t.Errorf("EOF reported as a bad frame")
+	}
+}
+
+func TestMaxFrameSize(t *testing.T) {
+	var buf bytes.Buffer
+	c := New(&buf, Varint)
+	c.MaxFrameSize = 8
+	if err := c.WriteFrame(make([]byte, 9)); err != ErrFrameSize {
+		t.Errorf("expected %v got %v", ErrFrameSize, err)
+	}
+	if err := c.WriteFrame(make([]byte, 8)); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := c.ReadFrame(); err != nil || len(f) != 8 {
+		t.Errorf("expected 8 bytes got %d (%v)", len(f), err)
+	}
+
+	buf.Write([]byte{9})
+	buf.Write(make([]byte, 9))
+	if _, err := c.ReadFrame(); err != ErrFrameSize {
+		t.Errorf("expected %v got %v", ErrFrameSize, err)
+	}
+}
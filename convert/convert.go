@@ -0,0 +1,176 @@
+// Package convert translates between the engine's types in chesster and the
+// protobuf messages in api.
+//
+// Going to api always works. Coming from api everything is checked, since it
+// comes straight off the wire; moves are matched against the legal moves of a
+// board, the same way chesster.ParseUCI does, so the result can be passed
+// straight to Game.DoMove.
+package convert
+
+import (
+	"errors"
+
+	api "github.com/cactorium/chesster-server/api"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+var (
+	ErrPosition = errors.New("position missing or off the board")
+	ErrType     = errors.New("bad piece type")
+	ErrSide     = errors.New("bad side")
+	ErrCastle   = errors.New("bad castle")
+	ErrNoMove   = errors.New("move doesn't match any legal move")
+)
+
+// the enums disagree on the order of kings and queens
+var types = map[chesster.PieceType]api.Type{
+	chesster.InvalidPiece: api.Type_INVALID,
+	chesster.Pawn:         api.Type_PAWN,
+	chesster.Rook:         api.Type_ROOK,
+	chesster.Knight:       api.Type_KNIGHT,
+	chesster.Bishop:       api.Type_BISHOP,
+	chesster.Queen:        api.Type_QUEEN,
+	chesster.King:         api.Type_KING,
+}
+
+var typesFromAPI = map[api.Type]chesster.PieceType{}
+
+func init() {
+	for t, at := range types {
+		typesFromAPI[at] = t
+	}
+}
+
+func Type(t chesster.PieceType) api.Type {
+	return types[t]
+}
+
+// converts a piece type; INVALID is an error too, since no piece has it
+func TypeFromAPI(t api.Type) (chesster.PieceType, error) {
+	ct, ok := typesFromAPI[t]
+	if !ok || ct == chesster.InvalidPiece {
+		return chesster.InvalidPiece, ErrType
+	}
+	return ct, nil
+}
+
+func Side(s chesster.Side) api.Side {
+	if s == chesster.Black {
+		return api.Side_BLACK
+	}
+	return api.Side_WHITE
+}
+
+func SideFromAPI(s api.Side) (chesster.Side, error) {
+	switch s {
+	case api.Side_WHITE:
+		return chesster.White, nil
+	case api.Side_BLACK:
+		return chesster.Black, nil
+	}
+	return chesster.White, ErrSide
+}
+
+func Position(x, y int) *api.Position {
+	return &api.Position{X: int32(x), Y: int32(y)}
+}
+
+func PositionFromAPI(p *api.Position) (int, int, error) {
+	if p == nil || p.X < 0 || p.X > 7 || p.Y < 0 || p.Y > 7 {
+		return 0, 0, ErrPosition
+	}
+	return int(p.X), int(p.Y), nil
+}
+
+func Piece(p chesster.Piece) *api.Piece {
+	return &api.Piece{
+		Type:     Type(p.Type),
+		Position: Position(p.X, p.Y),
+		Side:     Side(p.Side),
+		HasMoved: p.HasMoved,
+	}
+}
+
+func PieceFromAPI(p *api.Piece) (chesster.Piece, error) {
+	if p == nil {
+		return chesster.Piece{}, ErrType
+	}
+	t, err := TypeFromAPI(p.Type)
+	if err != nil {
+		return chesster.Piece{}, err
+	}
+	x, y, err := PositionFromAPI(p.Position)
+	if err != nil {
+		return chesster.Piece{}, err
+	}
+	s, err := SideFromAPI(p.Side)
+	if err != nil {
+		return chesster.Piece{}, err
+	}
+	return chesster.Piece{X: x, Y: y, Type: t, Side: s, HasMoved: p.HasMoved}, nil
+}
+
+// converts a move; type is the piece that ends up on the end square, so for
+// promotions it's the piece promoted to. Castles are the king's move, like in
+// chesster. The player ID is left for the caller
+func Move(m chesster.Move) *api.Move {
+	am := &api.Move{
+		Type:      Type(m.End.Type),
+		Start:     Position(m.Start.X, m.Start.Y),
+		End:       Position(m.End.X, m.End.Y),
+		Promotion: m.IsPromotion,
+		Castle:    api.Move_NONE,
+	}
+	if m.IsCastle {
+		if m.IsKingsideCastle {
+			am.Castle = api.Move_KINGSIDE
+		} else {
+			am.Castle = api.Move_QUEENSIDE
+		}
+	}
+	return am
+}
+
+// finds the legal move on b that m describes. A castle can also be sent as
+// the king moving two squares with castle left as NONE
+func MoveFromAPI(b *chesster.Board, m *api.Move) (chesster.Move, error) {
+	if m == nil {
+		return chesster.Move{}, ErrNoMove
+	}
+	t, err := TypeFromAPI(m.Type)
+	if err != nil {
+		return chesster.Move{}, err
+	}
+	sx, sy, err := PositionFromAPI(m.Start)
+	if err != nil {
+		return chesster.Move{}, err
+	}
+	ex, ey, err := PositionFromAPI(m.End)
+	if err != nil {
+		return chesster.Move{}, err
+	}
+	switch m.Castle {
+	case api.Move_NONE, api.Move_KINGSIDE, api.Move_QUEENSIDE:
+	default:
+		return chesster.Move{}, ErrCastle
+	}
+
+	for _, p := range b.Pieces {
+		if p.X != sx || p.Y != sy {
+			continue
+		}
+		if p.Side != b.SideToMove() {
+			return chesster.Move{}, ErrNoMove
+		}
+		for _, cm := range p.GetPossibleMoves(b) {
+			if cm.End.X != ex || cm.End.Y != ey || cm.End.Type != t || cm.IsPromotion != m.Promotion {
+				continue
+			}
+			if m.Castle != api.Move_NONE && (!cm.IsCastle || cm.IsKingsideCastle != (m.Castle == api.Move_KINGSIDE)) {
+				continue
+			}
+			return cm, nil
+		}
+	}
+	return chesster.Move{}, ErrNoMove
+}
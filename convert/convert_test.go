@@ -0,0 +1,273 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/cactorium/chesster-server/api"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+func TestType(t *testing.T) {
+	for ct, at := range map[chesster.PieceType]api.Type{
+		chesster.Pawn:   api.Type_PAWN,
+		chesster.Rook:   api.Type_ROOK,
+		chesster.Knight: api.Type_KNIGHT,
+		chesster.Bishop: api.Type_BISHOP,
+		chesster.Queen:  api.Type_QUEEN,
+		chesster.King:   api.Type_KING,
+	} {
+		if Type(ct) != at {
+			t.Errorf("expected %s got %s", at, Type(ct))
+		}
+		if back, err := TypeFromAPI(at); err != nil || back != ct {
+			t.Errorf("expected %d got %d (%v)", ct, back, err)
+		}
+	}
+	if _, err := TypeFromAPI(api.Type_INVALID); err != ErrType {
+		t.Errorf("expected %v got %v", ErrType, err)
+	}
+	if _, err := TypeFromAPI(api.Type(7)); err != ErrType {
+		t.Errorf("expected %v got %v", ErrType, err)
+	}
+}
+
+func TestPieceFromAPI(t *testing.T) {
+	p := chesster.Piece{X: 3, Y: 7, Type: chesster.Queen, Side: chesster.Black, HasMoved: true}
+	if back, err := PieceFromAPI(Piece(p)); err != nil || back != p {
+		t.Errorf("expected %v got %v (%v)", p, back, err)
+	}
+
+	cases := []struct {
+		p   *api.Piece
+		err error
+	}{
+		{nil, ErrType},
+		{&api.Piece{Type: api.Type_PAWN, Side: api.Side_WHITE}, ErrPosition},
+		{&api.Piece{Type: api.Type_PAWN, Position: &api.Position{X: 8, Y: 0}}, ErrPosition},
+		{&api.Piece{Type: api.Type_PAWN, Position: &api.Position{X: 0, Y: -1}}, ErrPosition},
+		{&api.Piece{Type: api.Type_PAWN, Position: &api.Position{}, Side: api.Side(2)}, ErrSide},
+		{&api.Piece{Position: &api.Position{}}, ErrType},
+	}
+	for i, c := range cases {
+		if _, err := PieceFromAPI(c.p); err != c.err {
+			t.Errorf("case %d: expected %v got %v", i, c.err, err)
+		}
+	}
+}
+
+func TestMoveFromAPI(t *testing.T) {
+	g, err := chesster.ParseFEN("r3k2r/1P6/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range g.Board.GetAllPossibleMoves(chesster.White) {
+		back, err := MoveFromAPI(&g.Board, Move(m))
+		if err != nil {
+			t.Errorf("%s: %s", m.UCI(), err)
+		} else if !back.Eq(m) {
+			t.Errorf("%s: got %s back", m.UCI(), back.UCI())
+		}
+	}
+
+	at := func(x, y int32) *api.Position {
+		return &api.Position{X: x, Y: y}
+	}
+	cases := []struct {
+		m   *api.Move
+		uci string
+		err error
+	}{
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(6, 0), Castle: api.Move_KINGSIDE}, "e1g1", nil},
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(2, 0)}, "e1c1", nil},
+		{&api.Move{Type: api.Type_QUEEN, Start: at(1, 6), End: at(0, 7), Promotion: true}, "b7a8q", nil},
+		{&api.Move{Type: api.Type_KNIGHT, Start: at(1, 6), End: at(1, 7), Promotion: true}, "b7b8n", nil},
+		// promotions have to say what they promote to
+		{&api.Move{Type: api.Type_PAWN, Start: at(1, 6), End: at(1, 7), Promotion: true}, "", ErrNoMove},
+		{&api.Move{Type: api.Type_QUEEN, Start: at(1, 6), End: at(1, 7)}, "", ErrNoMove},
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(6, 0), Castle: api.Move_QUEENSIDE}, "", ErrNoMove},
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(6, 0), Castle: api.Move_Castle(3)}, "", ErrCastle},
+		{&api.Move{Type: api.Type_ROOK, Start: at(0, 7), End: at(0, 6)}, "", ErrNoMove},
+		{&api.Move{Type: api.Type_ROOK, Start: at(0, 0), End: at(0, 8)}, "", ErrPosition},
+		{&api.Move{Start: at(0, 0), End: at(0, 1)}, "", ErrType},
+		{nil, "", ErrNoMove},
+	}
+	for i, c := range cases {
+		m, err := MoveFromAPI(&g.Board, c.m)
+		if err != c.err {
+			t.Errorf("case %d: expected %v got %v", i, c.err, err)
+		} else if err == nil && m.UCI() != c.uci {
+			t.Errorf("case %d: expected %s got %s", i, c.uci, m.UCI())
+		}
+	}
+}
+
+// plays UCI moves from the initial position
+func playGame(t *testing.T, moves ...string) chesster.Game {
+	g := chesster.NewGame()
+	for _, s := range moves {
+		m, err := chesster.ParseUCI(&g.Board, s)
+		if err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("%s: move rejected: %d", s, r)
+		}
+	}
+	return g
+}
+
+func TestGameFromAPI(t *testing.T) {
+	g := playGame(t, "e2e4", "d7d5", "e4d5", "g8f6", "f1b5", "c7c6", "d5c6", "d8d7", "c6b7", "e7e5", "b7a8q")
+	g.OfferDraw(chesster.Black)
+	back, err := GameFromAPI(Board(&g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.ToFEN() != g.ToFEN() || back.State != g.State || !back.BlackDrawAsk || back.WhiteDrawAsk {
+		t.Errorf("expected %s got %s", g.ToFEN(), back.ToFEN())
+	}
+	if !reflect.DeepEqual(Board(&back), Board(&g)) {
+		t.Errorf("board changed by round trip")
+	}
+
+	// fool's mate
+	g = playGame(t, "f2f3", "e7e5", "g2g4", "d8h4")
+	if s := GameState(&g); s != api.GameState_WhiteCheckmate && s != api.GameState_BlackCheckmate {
+		t.Fatalf("expected checkmate got %s", s)
+	}
+	if _, err := GameFromAPI(Board(&g)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSnapshotSummary(t *testing.T) {
+	for _, ucis := range [][]string{
+		{},
+		{"e2e4"},
+		{"e2e4", "f7f6", "d2d4", "g7g5", "d1h5"},
+		{"f2f3", "e7e5", "g2g4", "d8h4"},
+	} {
+		g := playGame(t, ucis...)
+		if s, want := SnapshotSummary(g.Snapshot()), Summary(&g); !reflect.DeepEqual(s, want) {
+			t.Errorf("%v: expected %v got %v", ucis, want, s)
+		}
+	}
+}
+
+func TestGameFromAPIErrors(t *testing.T) {
+	g := playGame(t, "e2e4", "e7e5")
+
+	b := Board(&g)
+	b.Inplay[0].Position = &api.Position{X: 4, Y: 4}
+	if _, err := GameFromAPI(b); err != ErrBoard {
+		t.Errorf("moved piece: expected %v got %v", ErrBoard, err)
+	}
+
+	b = Board(&g)
+	b.MoveList[1].End = &api.Position{X: 4, Y: 5}
+	if _, err := GameFromAPI(b); err != ErrBoard {
+		t.Errorf("different move: expected %v got %v", ErrBoard, err)
+	}
+	b.Inplay, b.Captured = nil, nil
+	if _, err := GameFromAPI(b); err != nil {
+		t.Errorf("moves only: %v", err)
+	}
+
+	b = Board(&g)
+	b.MoveList = append(b.MoveList, b.MoveList[0])
+	if _, err := GameFromAPI(b); err != ErrNoMove {
+		t.Errorf("illegal move: expected %v got %v", ErrNoMove, err)
+	}
+
+	b = Board(&g)
+	b.Gs.State = api.GameState_BlackMove
+	if _, err := GameFromAPI(b); err != ErrSummary {
+		t.Errorf("wrong turn: expected %v got %v", ErrSummary, err)
+	}
+
+	b = Board(&g)
+	b.Gs.State = api.GameState_WhiteCheckmate
+	if _, err := GameFromAPI(b); err != ErrSummary {
+		t.Errorf("made up checkmate: expected %v got %v", ErrSummary, err)
+	}
+
+	b = Board(&g)
+	b.Gs.State = api.GameState(20)
+	if _, err := GameFromAPI(b); err != ErrGameState {
+		t.Errorf("bad state: expected %v got %v", ErrGameState, err)
+	}
+
+	b = Board(&g)
+	b.Gs.State = api.GameState_WhiteResigned
+	back, err := GameFromAPI(b)
+	if err != nil || back.State != chesster.WhiteResigned {
+		t.Errorf("resignation: expected %d got %d (%v)", chesster.WhiteResigned, back.State, err)
+	}
+}
+
+func TestMoveError(t *testing.T) {
+	g, err := chesster.ParseFEN("4k3/8/8/8/8/8/3q4/R3K2R w KQ - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(x, y int32) *api.Position {
+		return &api.Position{X: x, Y: y}
+	}
+	cases := []struct {
+		m        *api.Move
+		expected api.MoveResult_Error
+	}{
+		{nil, api.MoveResult_MALFORMED_MOVE},
+		{&api.Move{Type: api.Type_ROOK, Start: at(0, 0)}, api.MoveResult_MALFORMED_MOVE},
+		{&api.Move{Start: at(0, 0), End: at(0, 1)}, api.MoveResult_MALFORMED_MOVE},
+		{&api.Move{Type: api.Type_ROOK, Start: at(0, 0), End: at(0, 8)}, api.MoveResult_OUT_OF_BOUNDS},
+		{&api.Move{Type: api.Type_ROOK, Start: at(1, 0), End: at(1, 1)}, api.MoveResult_PIECE_NOT_FOUND},
+		{&api.Move{Type: api.Type_QUEEN, Start: at(3, 1), End: at(3, 0)}, api.MoveResult_WRONG_SIDE},
+		{&api.Move{Type: api.Type_KING, Start: at(4, 7), End: at(4, 6)}, api.MoveResult_WRONG_SIDE},
+		// in check, so no castling, and the rook has to take the queen
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(6, 0)}, api.MoveResult_CANT_CASTLE},
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(2, 0), Castle: api.Move_QUEENSIDE}, api.MoveResult_CANT_CASTLE},
+		{&api.Move{Type: api.Type_ROOK, Start: at(0, 0), End: at(0, 1)}, api.MoveResult_IN_CHECK},
+		// but a move no rook could make is just invalid
+		{&api.Move{Type: api.Type_ROOK, Start: at(0, 0), End: at(1, 1)}, api.MoveResult_INVALID_MOVE},
+		{&api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(4, 2)}, api.MoveResult_INVALID_MOVE},
+	}
+	for i, c := range cases {
+		_, err := MoveFromAPI(&g.Board, c.m)
+		if err == nil {
+			t.Errorf("case %d: expected an error", i)
+		} else if e := MoveFromAPIError(&g.Board, c.m, err); e != c.expected {
+			t.Errorf("case %d: expected %s got %s", i, c.expected, e)
+		}
+	}
+
+	// castling with a rook that's moved is turned down as a castle, but a king
+	// off its home square moving two squares isn't castling at all
+	for i, c := range []struct {
+		fen      string
+		m        *api.Move
+		expected api.MoveResult_Error
+	}{
+		{"4k3/8/8/8/8/8/8/R3K2R w Q - 0 1", &api.Move{Type: api.Type_KING, Start: at(4, 0), End: at(6, 0)}, api.MoveResult_CANT_CASTLE},
+		{"4k3/8/8/8/8/8/8/R2K3R w - - 0 1", &api.Move{Type: api.Type_KING, Start: at(3, 0), End: at(5, 0)}, api.MoveResult_INVALID_MOVE},
+	} {
+		g, err := chesster.ParseFEN(c.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = MoveFromAPI(&g.Board, c.m)
+		if err == nil {
+			t.Errorf("%s: expected an error", c.fen)
+		} else if e := MoveFromAPIError(&g.Board, c.m, err); e != c.expected {
+			t.Errorf("case %d: expected %s got %s", i, c.expected, e)
+		}
+	}
+
+	if e := MoveError(chesster.AfraidOfCommitment); e != api.MoveResult_INVALID_MOVE {
+		t.Errorf("expected %s got %s", api.MoveResult_INVALID_MOVE, e)
+	}
+	if e := MoveError(chesster.MoveOkay); e != api.MoveResult_NO_ERROR {
+		t.Errorf("expected %s got %s", api.MoveResult_NO_ERROR, e)
+	}
+}
@@ -0,0 +1,261 @@
+package convert
+
+import (
+	"errors"
+	"strings"
+
+	api "github.com/cactorium/chesster-server/api"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+var (
+	ErrGameState = errors.New("bad game state")
+	ErrBoard     = errors.New("board doesn't match its move list")
+	ErrSummary   = errors.New("game summary doesn't match the board")
+)
+
+// api.GameState has no InPlay; it says whose turn it is instead
+var gameStates = map[chesster.GameState]api.GameState{
+	chesster.WhiteCheckmate:           api.GameState_WhiteCheckmate,
+	chesster.BlackCheckmate:           api.GameState_BlackCheckmate,
+	chesster.WhiteStalemate:           api.GameState_WhiteStalemate,
+	chesster.BlackStalemate:           api.GameState_BlackStalemate,
+	chesster.WhiteResigned:            api.GameState_WhiteResigned,
+	chesster.BlackResigned:            api.GameState_BlackResigned,
+	chesster.DrawAgreed:               api.GameState_DrawAgreed,
+	chesster.Draw50Moves:              api.GameState_Draw50Moves,
+	chesster.Draw3Fold:                api.GameState_Draw3Fold,
+	chesster.DrawInsufficientMaterial: api.GameState_DrawInsufficientMaterial,
+}
+
+var gameStatesFromAPI = map[api.GameState]chesster.GameState{
+	api.GameState_WhiteMove: chesster.InPlay,
+	api.GameState_BlackMove: chesster.InPlay,
+}
+
+func init() {
+	for s, as := range gameStates {
+		gameStatesFromAPI[as] = s
+	}
+}
+
+func GameState(g *chesster.Game) api.GameState {
+	if g.State == chesster.InPlay {
+		if g.Board.SideToMove() == chesster.Black {
+			return api.GameState_BlackMove
+		}
+		return api.GameState_WhiteMove
+	}
+	return gameStates[g.State]
+}
+
+// converts a game state; WhiteMove and BlackMove are both InPlay
+func GameStateFromAPI(s api.GameState) (chesster.GameState, error) {
+	gs, ok := gameStatesFromAPI[s]
+	if !ok {
+		return chesster.InPlay, ErrGameState
+	}
+	return gs, nil
+}
+
+// summarizes a game; the player lists are left for the caller
+func Summary(g *chesster.Game) *api.GameSummary {
+	return &api.GameSummary{
+		State:             GameState(g),
+		WhiteCheck:        g.WhiteCheck,
+		BlackCheck:        g.BlackCheck,
+		WhiteDraw:         g.WhiteDrawAsk,
+		BlackDraw:         g.BlackDrawAsk,
+		MovesSinceCapture: int64(g.MovesSinceCapture),
+	}
+}
+
+// summarizes a game from its snapshot without replaying it, so there are no
+// draw offers in it. Whose move it is comes from the FEN, so it needs one
+func SnapshotSummary(s chesster.Snapshot) *api.GameSummary {
+	st := gameStates[s.State]
+	if s.State == chesster.InPlay {
+		st = api.GameState_WhiteMove
+		if f := strings.Fields(s.FEN); len(f) > 1 && f[1] == "b" {
+			st = api.GameState_BlackMove
+		}
+	}
+	return &api.GameSummary{
+		State:             st,
+		WhiteCheck:        s.WhiteCheck,
+		BlackCheck:        s.BlackCheck,
+		MovesSinceCapture: int64(s.MovesSinceCapture),
+	}
+}
+
+func Board(g *chesster.Game) *api.Board {
+	b := &api.Board{
+		Inplay:   make([]*api.Piece, 0, len(g.Board.Pieces)),
+		Captured: make([]*api.Piece, 0, len(g.Board.Captured)),
+		MoveList: make([]*api.Move, 0, len(g.Moves)),
+		Gs:       Summary(g),
+	}
+	for _, p := range g.Board.Pieces {
+		b.Inplay = append(b.Inplay, Piece(p))
+	}
+	for _, p := range g.Board.Captured {
+		b.Captured = append(b.Captured, Piece(p))
+	}
+	for _, m := range g.Moves {
+		b.MoveList = append(b.MoveList, Move(m))
+	}
+	return b
+}
+
+// rebuilds a game by replaying its move list from the initial position. If
+// the pieces are there too they have to match the replayed board, and if the
+// summary is there it's checked against the game and supplies whatever the
+// moves can't: draw offers, resignations and claimed draws
+func GameFromAPI(b *api.Board) (chesster.Game, error) {
+	if b == nil {
+		return chesster.Game{}, ErrBoard
+	}
+	g := chesster.NewGame()
+	for _, am := range b.MoveList {
+		if g.GameEnded() {
+			return chesster.Game{}, ErrNoMove
+		}
+		m, err := MoveFromAPI(&g.Board, am)
+		if err != nil {
+			return chesster.Game{}, err
+		}
+		if ok, _ := g.DoMove(m); !ok {
+			return chesster.Game{}, ErrNoMove
+		}
+	}
+
+	if len(b.Inplay) > 0 || len(b.Captured) > 0 {
+		if err := matchPieces(b.Inplay, g.Board.Pieces); err != nil {
+			return chesster.Game{}, err
+		}
+		if err := matchPieces(b.Captured, g.Board.Captured); err != nil {
+			return chesster.Game{}, err
+		}
+	}
+
+	if b.Gs != nil {
+		if err := applySummary(&g, b.Gs); err != nil {
+			return chesster.Game{}, err
+		}
+	}
+	return g, nil
+}
+
+// checks that the pieces are the same, in any order
+func matchPieces(aps []*api.Piece, ps []chesster.Piece) error {
+	if len(aps) != len(ps) {
+		return ErrBoard
+	}
+	counts := map[chesster.Piece]int{}
+	for _, p := range ps {
+		counts[p]++
+	}
+	for _, ap := range aps {
+		p, err := PieceFromAPI(ap)
+		if err != nil {
+			return err
+		}
+		if counts[p] == 0 {
+			return ErrBoard
+		}
+		counts[p]--
+	}
+	return nil
+}
+
+func applySummary(g *chesster.Game, s *api.GameSummary) error {
+	state, err := GameStateFromAPI(s.State)
+	if err != nil {
+		return err
+	}
+	if s.WhiteCheck != g.WhiteCheck || s.BlackCheck != g.BlackCheck || s.MovesSinceCapture != int64(g.MovesSinceCapture) {
+		return ErrSummary
+	}
+
+	if g.State != chesster.InPlay || state == chesster.InPlay {
+		if GameState(g) != s.State {
+			return ErrSummary
+		}
+	} else {
+		// only endings that don't come from a move
+		switch state {
+		case chesster.WhiteResigned, chesster.BlackResigned, chesster.DrawAgreed:
+		case chesster.Draw3Fold:
+			if !g.CanClaimDraw3Fold() {
+				return ErrSummary
+			}
+		case chesster.DrawInsufficientMaterial:
+			// a flag fall against a side that can't mate
+			if g.Board.CanCheckmate(chesster.White) && g.Board.CanCheckmate(chesster.Black) {
+				return ErrSummary
+			}
+		default:
+			return ErrSummary
+		}
+		g.State = state
+	}
+	g.WhiteDrawAsk = s.WhiteDraw
+	g.BlackDrawAsk = s.BlackDraw
+	return nil
+}
+
+// api.MoveResult has fewer errors than chesster has reasons
+var moveErrors = map[chesster.InvalidMoveReason]api.MoveResult_Error{
+	chesster.MoveOkay:             api.MoveResult_NO_ERROR,
+	chesster.GameEnded:            api.MoveResult_GAME_ENDED,
+	chesster.DisloyaltyForbidden:  api.MoveResult_INVALID_MOVE,
+	chesster.WrongSide:            api.MoveResult_WRONG_SIDE,
+	chesster.OutOfBounds:          api.MoveResult_OUT_OF_BOUNDS,
+	chesster.PieceNotFound:        api.MoveResult_PIECE_NOT_FOUND,
+	chesster.TypeChangeNotAllowed: api.MoveResult_INVALID_MOVE,
+	chesster.InvalidMove:          api.MoveResult_INVALID_MOVE,
+	chesster.StillInCheck:         api.MoveResult_IN_CHECK,
+	chesster.OnlyOneKing:          api.MoveResult_INVALID_MOVE,
+	chesster.AfraidOfCommitment:   api.MoveResult_INVALID_MOVE,
+	chesster.CantCastle:           api.MoveResult_CANT_CASTLE,
+}
+
+// the error to send back for a move Game.DoMove turned down
+func MoveError(r chesster.InvalidMoveReason) api.MoveResult_Error {
+	if e, ok := moveErrors[r]; ok {
+		return e
+	}
+	return api.MoveResult_INVALID_MOVE
+}
+
+// the error to send back for a move MoveFromAPI turned down with err; why it
+// didn't match a legal move is left to b.CheckMove
+func MoveFromAPIError(b *chesster.Board, m *api.Move, err error) api.MoveResult_Error {
+	switch {
+	case m == nil || m.Start == nil || m.End == nil || err == ErrType || err == ErrCastle:
+		return api.MoveResult_MALFORMED_MOVE
+	case err == ErrPosition:
+		return api.MoveResult_OUT_OF_BOUNDS
+	case err != ErrNoMove:
+		return api.MoveResult_MALFORMED_MOVE
+	}
+	// MoveFromAPI already turned down anything these fail on
+	t, _ := TypeFromAPI(m.Type)
+	sx, sy, _ := PositionFromAPI(m.Start)
+	ex, ey, _ := PositionFromAPI(m.End)
+	for _, p := range b.Pieces {
+		if p.X != sx || p.Y != sy {
+			continue
+		}
+		end := p
+		end.X, end.Y, end.Type, end.HasMoved = ex, ey, t, true
+		return MoveError(b.CheckMove(chesster.Move{
+			Start:            p,
+			End:              end,
+			IsPromotion:      m.Promotion,
+			IsCastle:         m.Castle != api.Move_NONE,
+			IsKingsideCastle: m.Castle == api.Move_KINGSIDE,
+		}))
+	}
+	return api.MoveResult_PIECE_NOT_FOUND
+}
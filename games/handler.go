@@ -0,0 +1,413 @@
+// Package games is the server's GameHandler. It keeps the games being looked
+// at in memory, checks every move, resignation and draw offer against the
+// player making it, and writes them through to storage as they happen.
+//
+// Players are identified by their user names, so the player ids in requests
+// are names, and the player making a request is the user of its session.
+package games
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	api "github.com/cactorium/chesster-server/api"
+	auth "github.com/cactorium/chesster-server/auth"
+	chesster "github.com/cactorium/chesster-server/chesster"
+	convert "github.com/cactorium/chesster-server/convert"
+	server "github.com/cactorium/chesster-server/server"
+	storage "github.com/cactorium/chesster-server/storage"
+)
+
+type Handler struct {
+	Users auth.UserStore
+	Games storage.GameStore
+	// nil for time.Now
+	Clock func() time.Time
+
+	mu   sync.Mutex
+	live map[string]*liveGame
+}
+
+// a game that's been loaded, and replayed to check it
+type liveGame struct {
+	mu   sync.Mutex
+	info storage.Game
+	game chesster.Game
+}
+
+func NewHandler(users auth.UserStore, games storage.GameStore) *Handler {
+	return &Handler{
+		Users: users,
+		Games: games,
+		live:  map[string]*liveGame{},
+	}
+}
+
+func (h *Handler) now() time.Time {
+	if h.Clock != nil {
+		return h.Clock()
+	}
+	return time.Now()
+}
+
+func malformed(reason string) error {
+	return &server.RequestError{Code: api.InvalidRequest_MALFORMED_REQUEST, Reason: reason}
+}
+
+func notPlaying() error {
+	return &server.RequestError{Code: api.InvalidRequest_NOT_ALLOWED, Reason: "not playing in this game"}
+}
+
+func notSupported(reason string) error {
+	return &server.RequestError{Code: api.InvalidRequest_UNKNOWN_ERROR, Reason: reason}
+}
+
+// the game with id, loading it if it isn't yet. Only games in play are kept;
+// finished ones are loaded again each time they're looked at
+func (h *Handler) game(id []byte) (*liveGame, error) {
+	h.mu.Lock()
+	lg, ok := h.live[string(id)]
+	h.mu.Unlock()
+	if ok {
+		return lg, nil
+	}
+
+	// replaying a long game takes a while, so it's done without holding up
+	// every other game
+	g, info, err := storage.LoadGame(h.Games, id)
+	if err == storage.ErrNoGame {
+		return nil, malformed("no such game")
+	} else if he, ok := err.(*chesster.HistoryError); ok {
+		log.Printf("replaying game %x: %s", id, he)
+		return nil, &server.RequestError{Code: api.InvalidRequest_BAD_HISTORY, Reason: "game's history doesn't check out"}
+	} else if err != nil {
+		return nil, err
+	}
+	lg = &liveGame{info: info, game: g}
+	if g.GameEnded() {
+		return lg, nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// someone else may have loaded it meanwhile
+	if cur, ok := h.live[string(id)]; ok {
+		return cur, nil
+	}
+	h.live[string(id)] = lg
+	return lg, nil
+}
+
+// drops a game that's ended from the ones kept in memory
+func (h *Handler) forget(lg *liveGame) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.live[string(lg.info.ID)] == lg {
+		delete(h.live, string(lg.info.ID))
+	}
+}
+
+// the side user plays in the game, if they're in it
+func (lg *liveGame) side(user string) (chesster.Side, bool) {
+	switch {
+	case user == "":
+		return chesster.White, false
+	case user == lg.info.White:
+		return chesster.White, true
+	case user == lg.info.Black:
+		return chesster.Black, true
+	}
+	return chesster.White, false
+}
+
+func (lg *liveGame) summary() *api.GameSummary {
+	s := convert.Summary(&lg.game)
+	s.GameId = lg.info.ID
+	s.White = [][]byte{[]byte(lg.info.White)}
+	s.Black = [][]byte{[]byte(lg.info.Black)}
+	return s
+}
+
+// does each action in turn. Once one fails, it gets the error in place of a
+// result and the rest aren't done, but the results of those already done are
+// still sent back, as they've been saved
+func (h *Handler) HandleGame(ctx context.Context, c *server.Conn, req *api.GameRequest) (*api.GameResponse, error) {
+	var user string
+	if sess, ok := auth.SessionFrom(ctx); ok && !sess.Guest {
+		user = sess.User
+	}
+	for _, pr := range req.Ps {
+		if len(pr.PlayerId) > 0 && string(pr.PlayerId) != user {
+			return nil, &server.RequestError{Code: api.InvalidRequest_NOT_ALLOWED, Reason: "can only act as yourself"}
+		}
+	}
+	resp := &api.GameResponse{}
+	for _, pr := range req.Ps {
+		presp := &api.PlayerResp{PlayerId: []byte(user)}
+		resp.Ps = append(resp.Ps, presp)
+		for _, a := range pr.Actions {
+			r, err := h.playerAction(user, a)
+			if err != nil {
+				r = &api.PlayerResult{Error: actionError(err)}
+			}
+			r.ActionId = a.ActionId
+			presp.Results = append(presp.Results, r)
+			if err != nil {
+				return resp, nil
+			}
+		}
+	}
+	for _, gr := range req.Gs {
+		gresp := &api.GameResp{GameId: gr.GameId}
+		resp.Gs = append(resp.Gs, gresp)
+		lg, err := h.game(gr.GameId)
+		if err != nil {
+			// the first action's the one that failed
+			r := &api.GameResult{Error: actionError(err)}
+			if len(gr.Actions) > 0 {
+				r.ActionId = gr.Actions[0].ActionId
+			}
+			gresp.Results = append(gresp.Results, r)
+			return resp, nil
+		}
+		for _, a := range gr.Actions {
+			r, err := h.gameAction(user, lg, a)
+			if err != nil {
+				r = &api.GameResult{Error: actionError(err)}
+			}
+			r.ActionId = a.ActionId
+			gresp.Results = append(gresp.Results, r)
+			if err != nil {
+				return resp, nil
+			}
+		}
+	}
+	return resp, nil
+}
+
+// what's sent back for an action that failed with err
+func actionError(err error) *api.InvalidRequest {
+	if re, ok := err.(*server.RequestError); ok {
+		return &api.InvalidRequest{Code: re.Code, Reason: re.Reason}
+	}
+	log.Printf("game action: %s", err)
+	return &api.InvalidRequest{Code: api.InvalidRequest_UNKNOWN_ERROR, Reason: "internal error"}
+}
+
+func (h *Handler) playerAction(user string, a *api.PlayerAction) (*api.PlayerResult, error) {
+	switch p := a.Actions.(type) {
+	case *api.PlayerAction_ListGames:
+		return h.listGames()
+	case *api.PlayerAction_StartGame:
+		return h.startGame(user, p.StartGame)
+	case nil:
+		return nil, malformed("missing player action")
+	}
+	return nil, notSupported("player action not supported")
+}
+
+// every game in play, for finding one to spectate. Games that aren't being
+// played right now are summarized from what's stored, rather than loading
+// them all
+func (h *Handler) listGames() (*api.PlayerResult, error) {
+	infos, err := h.Games.ActiveGames()
+	if err != nil {
+		return nil, err
+	}
+	ss := &api.GameSummaries{}
+	for _, info := range infos {
+		h.mu.Lock()
+		lg, ok := h.live[string(info.ID)]
+		h.mu.Unlock()
+		if !ok && info.Snapshot.FEN != "" {
+			s := convert.SnapshotSummary(info.Snapshot)
+			s.GameId = info.ID
+			s.White = [][]byte{[]byte(info.White)}
+			s.Black = [][]byte{[]byte(info.Black)}
+			ss.S = append(ss.S, s)
+			continue
+		}
+		if !ok {
+			// stored before snapshots were kept, so it has to be replayed
+			if lg, err = h.game(info.ID); err != nil {
+				return nil, err
+			}
+		}
+		lg.mu.Lock()
+		ss.S = append(ss.S, lg.summary())
+		lg.mu.Unlock()
+	}
+	return &api.PlayerResult{Results: &api.PlayerResult_Games{Games: ss}}, nil
+}
+
+// starts a game between two users, one of them the one asking
+func (h *Handler) startGame(user string, req *api.StartGame) (*api.PlayerResult, error) {
+	if len(req.WhiteIds) != 1 || len(req.BlackIds) != 1 {
+		return nil, malformed("a game needs one white and one black player")
+	}
+	white, err := h.player(req.WhiteIds[0])
+	if err != nil {
+		return nil, err
+	}
+	black, err := h.player(req.BlackIds[0])
+	if err != nil {
+		return nil, err
+	}
+	if white == black {
+		return nil, malformed("a game needs two different players")
+	}
+	if user == "" || (user != white && user != black) {
+		return nil, &server.RequestError{Code: api.InvalidRequest_NOT_ALLOWED, Reason: "can only start your own games"}
+	}
+
+	id, err := storage.NewGameID()
+	if err != nil {
+		return nil, err
+	}
+	g := chesster.NewGame()
+	info := storage.Game{ID: id, White: white, Black: black, Snapshot: g.Snapshot(), Started: h.now()}
+	if err := h.Games.CreateGame(info); err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.live[string(id)] = &liveGame{info: info, game: g}
+	h.mu.Unlock()
+	return &api.PlayerResult{Results: &api.PlayerResult_GameId{GameId: id}}, nil
+}
+
+// the user a player id names
+func (h *Handler) player(id []byte) (string, error) {
+	name, err := auth.NormalizeName(string(id))
+	if err != nil {
+		return "", malformed("no such player")
+	}
+	if _, err := h.Users.User(name); err == auth.ErrNoUser {
+		return "", malformed("no such player")
+	} else if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (h *Handler) gameAction(user string, lg *liveGame, a *api.GameAction) (*api.GameResult, error) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	switch p := a.Actions.(type) {
+	case *api.GameAction_GameSummary:
+		return &api.GameResult{Actions: &api.GameResult_Summary{Summary: lg.summary()}}, nil
+	case *api.GameAction_Board:
+		b := convert.Board(&lg.game)
+		b.Gs = lg.summary()
+		return &api.GameResult{Actions: &api.GameResult_Board{Board: b}}, nil
+	case *api.GameAction_History:
+		ms := &api.MoveList{}
+		for _, m := range lg.game.Moves {
+			ms.Ms = append(ms.Ms, convert.Move(m))
+		}
+		return &api.GameResult{Actions: &api.GameResult_Moves{Moves: ms}}, nil
+	case *api.GameAction_PlayMove:
+		r, err := h.playMove(user, lg, p.PlayMove.GetMove())
+		return &api.GameResult{Actions: &api.GameResult_MoveResult{MoveResult: r}}, err
+	case *api.GameAction_Resign:
+		r, err := h.resign(user, lg)
+		return &api.GameResult{Actions: &api.GameResult_ResignResult{ResignResult: r}}, err
+	case *api.GameAction_Draw:
+		r, err := h.draw(user, lg)
+		return &api.GameResult{Actions: &api.GameResult_DrawResult{DrawResult: r}}, err
+	case nil:
+		return nil, malformed("missing game action")
+	}
+	return nil, notSupported("game action not supported")
+}
+
+// plays a move for the side to move, if that's user. The move's turned down
+// with the reason in the result if it's not allowed
+func (h *Handler) playMove(user string, lg *liveGame, am *api.Move) (*api.MoveResult, error) {
+	g := &lg.game
+	fail := func(e api.MoveResult_Error) (*api.MoveResult, error) {
+		return &api.MoveResult{Error: e, Result: lg.summary()}, nil
+	}
+	if g.GameEnded() {
+		return fail(api.MoveResult_GAME_ENDED)
+	}
+	if s, ok := lg.side(user); !ok || s != g.Board.SideToMove() {
+		return fail(api.MoveResult_NOT_YOUR_TURN)
+	}
+	m, err := convert.MoveFromAPI(&g.Board, am)
+	if err != nil {
+		return fail(convert.MoveFromAPIError(&g.Board, am, err))
+	}
+	if ok, r := g.DoMove(m); !ok {
+		return fail(convert.MoveError(r))
+	}
+
+	after := g.Snapshot()
+	mv := storage.Move{Ply: len(g.Moves) - 1, UCI: m.UCI(), Played: h.now()}
+	if err := h.Games.AddMove(lg.info.ID, mv, after); err != nil {
+		g.Undo()
+		return nil, err
+	}
+	lg.info.Snapshot = after
+	if g.GameEnded() {
+		lg.info.Finished = mv.Played
+		h.forget(lg)
+	}
+	return &api.MoveResult{Success: true, Result: lg.summary()}, nil
+}
+
+// user gives up; only players can
+func (h *Handler) resign(user string, lg *liveGame) (*api.ResignResult, error) {
+	s, ok := lg.side(user)
+	if !ok {
+		return nil, notPlaying()
+	}
+	if !lg.game.Resign(s) {
+		return &api.ResignResult{Error: api.ResignResult_GAME_ENDED, Result: lg.summary()}, nil
+	}
+	if err := h.finish(lg); err != nil {
+		return nil, err
+	}
+	return &api.ResignResult{Success: true, Result: lg.summary()}, nil
+}
+
+// offers user's opponent a draw, or accepts theirs. If the position has come
+// up three times and it's user's move the draw's claimed straight away instead.
+// Offers aren't stored, only the draw once it's agreed, so one still open when
+// the server restarts has to be made again; one also lapses when the other
+// side moves instead of accepting it
+func (h *Handler) draw(user string, lg *liveGame) (*api.DrawResult, error) {
+	g := &lg.game
+	s, ok := lg.side(user)
+	if !ok {
+		return nil, notPlaying()
+	}
+	if g.GameEnded() {
+		return &api.DrawResult{Error: api.DrawResult_GAME_ENDED, Result: lg.summary()}, nil
+	}
+	white, black := g.WhiteDrawAsk, g.BlackDrawAsk
+	if !g.ClaimDraw3Fold(s) {
+		g.OfferDraw(s)
+	}
+	if g.GameEnded() {
+		if err := h.finish(lg); err != nil {
+			g.WhiteDrawAsk, g.BlackDrawAsk = white, black
+			return nil, err
+		}
+	}
+	return &api.DrawResult{Success: true, Result: lg.summary()}, nil
+}
+
+// records the result of a game that just ended without a move; if that
+// fails it's back in play
+func (h *Handler) finish(lg *liveGame) error {
+	at := h.now()
+	if err := h.Games.FinishGame(lg.info.ID, lg.game.State, at); err != nil {
+		lg.game.State = chesster.InPlay
+		return err
+	}
+	lg.info.State = lg.game.State
+	lg.info.Finished = at
+	h.forget(lg)
+	return nil
+}
@@ -0,0 +1,402 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/cactorium/chesster-server/api"
+	auth "github.com/cactorium/chesster-server/auth"
+	chesster "github.com/cactorium/chesster-server/chesster"
+	server "github.com/cactorium/chesster-server/server"
+	storage "github.com/cactorium/chesster-server/storage"
+)
+
+func newHandler(t *testing.T) (*Handler, *storage.Memory) {
+	store := storage.NewMemory()
+	for _, name := range []string{"alice", "bob", "carol"} {
+		if err := store.Add(auth.User{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewHandler(store, store), store
+}
+
+// a context for requests from user's session
+func as(user string) context.Context {
+	return auth.WithSession(context.Background(), auth.Session{User: user, Authenticated: true})
+}
+
+func expectCode(t *testing.T, err error, code api.InvalidRequest_Code) {
+	t.Helper()
+	if re, ok := err.(*server.RequestError); !ok || re.Code != code {
+		t.Errorf("expected %s got %v", code, err)
+	}
+}
+
+// the error for a whole request, or else for the action in it that failed
+func failed(resp *api.GameResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, p := range resp.Ps {
+		for _, r := range p.Results {
+			if e := r.Error; e != nil {
+				return &server.RequestError{Code: e.Code, Reason: e.Reason}
+			}
+		}
+	}
+	for _, g := range resp.Gs {
+		for _, r := range g.Results {
+			if e := r.Error; e != nil {
+				return &server.RequestError{Code: e.Code, Reason: e.Reason}
+			}
+		}
+	}
+	return nil
+}
+
+func start(t *testing.T, h *Handler, user, white, black string) []byte {
+	t.Helper()
+	resp, err := h.HandleGame(as(user), nil, &api.GameRequest{Ps: []*api.PlayerReq{{
+		Actions: []*api.PlayerAction{{
+			ActionId: []byte("start"),
+			Actions:  &api.PlayerAction_StartGame{StartGame: &api.StartGame{WhiteIds: [][]byte{[]byte(white)}, BlackIds: [][]byte{[]byte(black)}}},
+		}},
+	}}})
+	if err := failed(resp, err); err != nil {
+		t.Fatal(err)
+	}
+	r := resp.Ps[0].Results[0]
+	if string(r.ActionId) != "start" || len(r.GetGameId()) != storage.GameIDSize {
+		t.Fatalf("expected a game id got %v", r)
+	}
+	return r.GetGameId()
+}
+
+// does one action in a game as user
+func act(t *testing.T, h *Handler, user string, id []byte, a *api.GameAction) *api.GameResult {
+	t.Helper()
+	resp, err := h.HandleGame(as(user), nil, &api.GameRequest{Gs: []*api.GameReq{{GameId: id, Actions: []*api.GameAction{a}}}})
+	if err := failed(resp, err); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Gs[0].Results[0]
+}
+
+// the error from one action in a game as user
+func actErr(h *Handler, user string, id []byte, a *api.GameAction) error {
+	return failed(h.HandleGame(as(user), nil, &api.GameRequest{Gs: []*api.GameReq{{GameId: id, Actions: []*api.GameAction{a}}}}))
+}
+
+// plays a move as user; typ is the piece that ends up on the end square
+func move(t *testing.T, h *Handler, user string, id []byte, typ api.Type, uci string) *api.MoveResult {
+	t.Helper()
+	sq := func(s string) *api.Position {
+		return &api.Position{X: int32(s[0] - 'a'), Y: int32(s[1] - '1')}
+	}
+	m := &api.Move{Type: typ, Start: sq(uci[0:2]), End: sq(uci[2:4])}
+	return act(t, h, user, id, &api.GameAction{Actions: &api.GameAction_PlayMove{PlayMove: &api.PlayMove{Move: m}}}).GetMoveResult()
+}
+
+func TestStartGame(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "alice", "alice", "Bob")
+	g, err := store.Game(id)
+	if err != nil || g.White != "alice" || g.Black != "bob" || g.State != chesster.InPlay {
+		t.Errorf("expected alice vs bob got %+v, %v", g, err)
+	}
+
+	startWith := func(user string, white, black [][]byte) error {
+		return failed(h.HandleGame(as(user), nil, &api.GameRequest{Ps: []*api.PlayerReq{{
+			Actions: []*api.PlayerAction{{Actions: &api.PlayerAction_StartGame{StartGame: &api.StartGame{WhiteIds: white, BlackIds: black}}}},
+		}}}))
+	}
+	names := func(ns ...string) [][]byte {
+		var bs [][]byte
+		for _, n := range ns {
+			bs = append(bs, []byte(n))
+		}
+		return bs
+	}
+	expectCode(t, startWith("carol", names("alice"), names("bob")), api.InvalidRequest_NOT_ALLOWED)
+	expectCode(t, startWith("", names("alice"), names("bob")), api.InvalidRequest_NOT_ALLOWED)
+	expectCode(t, startWith("alice", names("alice"), names("dave")), api.InvalidRequest_MALFORMED_REQUEST)
+	expectCode(t, startWith("alice", names("alice"), names("alice")), api.InvalidRequest_MALFORMED_REQUEST)
+	expectCode(t, startWith("alice", names("alice", "carol"), names("bob")), api.InvalidRequest_MALFORMED_REQUEST)
+
+	// can't act as someone else
+	_, err = h.HandleGame(as("carol"), nil, &api.GameRequest{Ps: []*api.PlayerReq{{
+		PlayerId: []byte("alice"),
+		Actions:  []*api.PlayerAction{{Actions: &api.PlayerAction_ListGames{ListGames: &api.ListActiveGames{}}}},
+	}}})
+	expectCode(t, err, api.InvalidRequest_NOT_ALLOWED)
+
+	resp, err := h.HandleGame(as("carol"), nil, &api.GameRequest{Ps: []*api.PlayerReq{{
+		Actions: []*api.PlayerAction{{Actions: &api.PlayerAction_ListGames{ListGames: &api.ListActiveGames{}}}},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := resp.Ps[0].Results[0].GetGames().GetS()
+	if len(ss) != 1 || string(ss[0].White[0]) != "alice" || ss[0].State != api.GameState_WhiteMove {
+		t.Fatalf("expected alice's game got %v", ss)
+	}
+	if string(ss[0].GameId) != string(id) {
+		t.Errorf("expected game id %x got %x", id, ss[0].GameId)
+	}
+
+	// games nobody's looked at since a restart are listed without loading them
+	move(t, h, "alice", id, api.Type_PAWN, "e2e4")
+	h = NewHandler(store, store)
+	resp, err = h.HandleGame(as("carol"), nil, &api.GameRequest{Ps: []*api.PlayerReq{{
+		Actions: []*api.PlayerAction{{Actions: &api.PlayerAction_ListGames{ListGames: &api.ListActiveGames{}}}},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss = resp.Ps[0].Results[0].GetGames().GetS()
+	if len(ss) != 1 || string(ss[0].Black[0]) != "bob" || ss[0].State != api.GameState_BlackMove {
+		t.Fatalf("expected alice's game with black to move got %v", ss)
+	}
+	if len(h.live) != 0 {
+		t.Errorf("listing loaded %d games", len(h.live))
+	}
+	// and the id it's listed with can be played on
+	if r := move(t, h, "bob", ss[0].GameId, api.Type_PAWN, "e7e5"); !r.GetSuccess() {
+		t.Errorf("expected to move in the listed game got %v", r)
+	}
+}
+
+func TestPlayMove(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "bob", "alice", "bob")
+
+	cases := []struct {
+		user     string
+		typ      api.Type
+		uci      string
+		expected api.MoveResult_Error
+	}{
+		{"bob", api.Type_PAWN, "e7e5", api.MoveResult_NOT_YOUR_TURN},
+		{"carol", api.Type_PAWN, "e2e4", api.MoveResult_NOT_YOUR_TURN},
+		{"alice", api.Type_PAWN, "e2e5", api.MoveResult_INVALID_MOVE},
+		{"alice", api.Type_PAWN, "e3e4", api.MoveResult_PIECE_NOT_FOUND},
+		{"alice", api.Type_PAWN, "e7e5", api.MoveResult_WRONG_SIDE},
+		{"alice", api.Type_PAWN, "e2e4", api.MoveResult_NO_ERROR},
+		{"alice", api.Type_PAWN, "d2d4", api.MoveResult_NOT_YOUR_TURN},
+		{"bob", api.Type_PAWN, "e7e5", api.MoveResult_NO_ERROR},
+		{"alice", api.Type_QUEEN, "d1h5", api.MoveResult_NO_ERROR},
+		{"bob", api.Type_KNIGHT, "b8c6", api.MoveResult_NO_ERROR},
+		{"alice", api.Type_BISHOP, "f1c4", api.MoveResult_NO_ERROR},
+		{"bob", api.Type_KNIGHT, "g8f6", api.MoveResult_NO_ERROR},
+		{"alice", api.Type_QUEEN, "h5f7", api.MoveResult_NO_ERROR},
+		{"bob", api.Type_KING, "e8f7", api.MoveResult_GAME_ENDED},
+	}
+	for i, c := range cases {
+		r := move(t, h, c.user, id, c.typ, c.uci)
+		if r.Error != c.expected || r.Success != (c.expected == api.MoveResult_NO_ERROR) {
+			t.Errorf("case %d: %s %s: expected %s got %s", i, c.user, c.uci, c.expected, r.Error)
+		}
+	}
+
+	ms, err := store.Moves(id)
+	if err != nil || len(ms) != 7 || ms[0].UCI != "e2e4" || ms[6].UCI != "h5f7" || ms[0].FEN == "" {
+		t.Errorf("expected the 7 moves played got %+v, %v", ms, err)
+	}
+	g, err := store.Game(id)
+	if err != nil || g.State != chesster.WhiteCheckmate || g.Finished.IsZero() {
+		t.Errorf("expected the game finished by checkmate got %+v, %v", g, err)
+	}
+
+	if _, ok := h.live[string(id)]; ok {
+		t.Errorf("finished game still kept")
+	}
+
+	// a restart picks up where it left off
+	h = NewHandler(store, store)
+	s := act(t, h, "carol", id, &api.GameAction{Actions: &api.GameAction_GameSummary{GameSummary: &api.GetSummary{}}}).GetSummary()
+	if s.State != api.GameState_WhiteCheckmate || !s.BlackCheck {
+		t.Errorf("expected checkmate got %v", s)
+	}
+	ml := act(t, h, "carol", id, &api.GameAction{Actions: &api.GameAction_History{History: &api.GetMoveHistory{}}}).GetMoves()
+	if len(ml.GetMs()) != 7 {
+		t.Errorf("expected 7 moves got %v", ml)
+	}
+}
+
+func TestResign(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "alice", "alice", "bob")
+	a := &api.GameAction{Actions: &api.GameAction_Resign{Resign: &api.Resign{}}}
+	resign := func(user string) *api.ResignResult {
+		return act(t, h, user, id, a).GetResignResult()
+	}
+	expectCode(t, actErr(h, "carol", id, a), api.InvalidRequest_NOT_ALLOWED)
+	if r := resign("bob"); !r.Success || r.Error != api.ResignResult_NO_ERROR || r.Result.State != api.GameState_BlackResigned {
+		t.Errorf("expected black resigned got %v", r)
+	}
+	if r := resign("alice"); r.Success || r.Error != api.ResignResult_GAME_ENDED {
+		t.Errorf("expected %s got %v", api.ResignResult_GAME_ENDED, r)
+	}
+	if g, _ := store.Game(id); g.State != chesster.BlackResigned || g.Finished.IsZero() {
+		t.Errorf("expected black resigned got %+v", g)
+	}
+	if r := move(t, h, "alice", id, api.Type_PAWN, "e2e4"); r.Error != api.MoveResult_GAME_ENDED {
+		t.Errorf("expected %s got %s", api.MoveResult_GAME_ENDED, r.Error)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "alice", "alice", "bob")
+	a := &api.GameAction{Actions: &api.GameAction_Draw{Draw: &api.Draw{}}}
+	draw := func(user string) *api.DrawResult {
+		return act(t, h, user, id, a).GetDrawResult()
+	}
+	expectCode(t, actErr(h, "carol", id, a), api.InvalidRequest_NOT_ALLOWED)
+	if r := draw("alice"); !r.Success || !r.Result.WhiteDraw || r.Result.State != api.GameState_WhiteMove {
+		t.Errorf("expected white's offer got %v", r)
+	}
+	if g, _ := store.Game(id); g.State != chesster.InPlay {
+		t.Errorf("game ended on an offer: %+v", g)
+	}
+	if r := draw("bob"); !r.Success || r.Result.State != api.GameState_DrawAgreed {
+		t.Errorf("expected a draw got %v", r)
+	}
+	if g, _ := store.Game(id); g.State != chesster.DrawAgreed || g.Finished.IsZero() {
+		t.Errorf("expected the draw stored got %+v", g)
+	}
+	if r := draw("alice"); r.Success || r.Error != api.DrawResult_GAME_ENDED {
+		t.Errorf("expected %s got %v", api.DrawResult_GAME_ENDED, r)
+	}
+}
+
+// a GameStore that can't record results
+type noFinish struct{ *storage.Memory }
+
+func (noFinish) FinishGame(id []byte, s chesster.GameState, at time.Time) error {
+	return errors.New("disk full")
+}
+
+func TestDrawLapses(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "alice", "alice", "bob")
+	draw := func(user string) *api.DrawResult {
+		return act(t, h, user, id, &api.GameAction{Actions: &api.GameAction_Draw{Draw: &api.Draw{}}}).GetDrawResult()
+	}
+	draw("alice")
+	if r := move(t, h, "alice", id, api.Type_PAWN, "e2e4"); !r.Result.WhiteDraw {
+		t.Errorf("offer withdrawn by white moving: %v", r)
+	}
+	if r := move(t, h, "bob", id, api.Type_PAWN, "e7e5"); r.Result.WhiteDraw {
+		t.Errorf("offer still open after black played on: %v", r)
+	}
+
+	// an agreement that can't be stored leaves things as they were
+	draw("bob")
+	h.Games = noFinish{store}
+	if err := actErr(h, "alice", id, &api.GameAction{Actions: &api.GameAction_Draw{Draw: &api.Draw{}}}); err == nil {
+		t.Fatal("expected the draw to fail")
+	}
+	h.Games = store
+	s := act(t, h, "carol", id, &api.GameAction{Actions: &api.GameAction_GameSummary{GameSummary: &api.GetSummary{}}}).GetSummary()
+	if s.State != api.GameState_WhiteMove || s.WhiteDraw || !s.BlackDraw {
+		t.Errorf("expected only black's offer open got %v", s)
+	}
+}
+
+func TestSpectate(t *testing.T) {
+	h, _ := newHandler(t)
+	id := start(t, h, "alice", "alice", "bob")
+	move(t, h, "alice", id, api.Type_PAWN, "e2e4")
+
+	guest := auth.WithSession(context.Background(), auth.Session{Guest: true, Authenticated: true})
+	resp, err := h.HandleGame(guest, nil, &api.GameRequest{Gs: []*api.GameReq{{GameId: id, Actions: []*api.GameAction{
+		{ActionId: []byte("b"), Actions: &api.GameAction_Board{Board: &api.GetBoard{}}},
+		{ActionId: []byte("m"), Actions: &api.GameAction_PlayMove{PlayMove: &api.PlayMove{Move: &api.Move{}}}},
+	}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := resp.Gs[0].Results
+	if b := rs[0].GetBoard(); string(rs[0].ActionId) != "b" || len(b.GetInplay()) != 32 || len(b.GetMoveList()) != 1 || b.GetGs().State != api.GameState_BlackMove {
+		t.Errorf("expected the board after e2e4 got %v", rs[0])
+	}
+	if r := rs[1].GetMoveResult(); r.Success || r.Error != api.MoveResult_NOT_YOUR_TURN {
+		t.Errorf("guest moved: %v", r)
+	}
+
+	expectCode(t, failed(h.HandleGame(guest, nil, &api.GameRequest{Gs: []*api.GameReq{{GameId: []byte("nope")}}})), api.InvalidRequest_MALFORMED_REQUEST)
+}
+
+func TestBadHistory(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "alice", "alice", "bob")
+	move(t, h, "alice", id, api.Type_PAWN, "e2e4")
+
+	// stored as if another move had been played
+	g, err := store.Game(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Snapshot.FEN = "rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq - 0 1"
+	if err := store.AddMove(id, storage.Move{Ply: 1, UCI: "e7e5"}, g.Snapshot); err != nil {
+		t.Fatal(err)
+	}
+	h = NewHandler(store, store)
+	expectCode(t, failed(h.HandleGame(as("alice"), nil, &api.GameRequest{Gs: []*api.GameReq{{GameId: id}}})), api.InvalidRequest_BAD_HISTORY)
+}
+
+func TestPartialResults(t *testing.T) {
+	h, store := newHandler(t)
+	id := start(t, h, "alice", "alice", "bob")
+
+	// the move's saved before the missing action fails, so its result is
+	// still sent back, and the action after isn't done
+	m := &api.Move{Type: api.Type_PAWN, Start: &api.Position{X: 4, Y: 1}, End: &api.Position{X: 4, Y: 3}}
+	resp, err := h.HandleGame(as("alice"), nil, &api.GameRequest{Gs: []*api.GameReq{{GameId: id, Actions: []*api.GameAction{
+		{ActionId: []byte("move"), Actions: &api.GameAction_PlayMove{PlayMove: &api.PlayMove{Move: m}}},
+		{ActionId: []byte("missing")},
+		{ActionId: []byte("resign"), Actions: &api.GameAction_Resign{Resign: &api.Resign{}}},
+	}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := resp.Gs[0].Results
+	if len(rs) != 2 {
+		t.Fatalf("expected 2 results got %v", rs)
+	}
+	if !rs[0].GetMoveResult().GetSuccess() || rs[0].Error != nil {
+		t.Errorf("expected the move played got %v", rs[0])
+	}
+	if string(rs[1].ActionId) != "missing" || rs[1].Error.GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+		t.Errorf("expected %s for the missing action got %v", api.InvalidRequest_MALFORMED_REQUEST, rs[1])
+	}
+	if ms, err := store.Moves(id); err != nil || len(ms) != 1 {
+		t.Errorf("expected the move saved got %v, %v", ms, err)
+	}
+	if g, err := store.Game(id); err != nil || g.State != chesster.InPlay {
+		t.Errorf("expected the game still in play got %+v, %v", g, err)
+	}
+
+	// and the same for player actions, with game actions after them not done
+	resp, err = h.HandleGame(as("alice"), nil, &api.GameRequest{
+		Ps: []*api.PlayerReq{{Actions: []*api.PlayerAction{
+			{ActionId: []byte("start"), Actions: &api.PlayerAction_StartGame{StartGame: &api.StartGame{WhiteIds: [][]byte{[]byte("alice")}, BlackIds: [][]byte{[]byte("bob")}}}},
+			{ActionId: []byte("bad"), Actions: &api.PlayerAction_StartGame{StartGame: &api.StartGame{}}},
+		}}},
+		Gs: []*api.GameReq{{GameId: id, Actions: []*api.GameAction{{Actions: &api.GameAction_Resign{Resign: &api.Resign{}}}}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := resp.Ps[0].Results
+	if len(ps) != 2 || len(ps[0].GetGameId()) != storage.GameIDSize || ps[1].Error.GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+		t.Errorf("expected a new game then %s got %v", api.InvalidRequest_MALFORMED_REQUEST, ps)
+	}
+	if len(resp.Gs) != 0 {
+		t.Errorf("expected no game results got %v", resp.Gs)
+	}
+}
@@ -0,0 +1,328 @@
+// Package server accepts client connections for chessterd and passes the
+// api.Message requests on them to the auth and game handlers.
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	api "github.com/cactorium/chesster-server/api"
+	codec "github.com/cactorium/chesster-server/codec"
+)
+
+// the only protocol version so far
+const Version = 0
+
+const DefaultAddr = ":8888"
+
+const DefaultShutdownTimeout = 5 * time.Second
+
+// handles the AuthRequest payloads from one connection
+type AuthHandler interface {
+	HandleAuth(ctx context.Context, c *Conn, req *api.AuthRequest) (*api.AuthResponse, error)
+}
+
+// handles the GameRequest payloads from one connection
+type GameHandler interface {
+	HandleGame(ctx context.Context, c *Conn, req *api.GameRequest) (*api.GameResponse, error)
+}
+
+// sits between the connection and the handlers, for checking and signing
+// headers
+type Middleware interface {
+	// called on each request before it's handled; an error is sent back
+	// instead of handling it. The context returned is passed to the handler
+	Request(ctx context.Context, c *Conn, req *api.Message) (context.Context, error)
+	// called on each reply before it's sent, with the request it answers
+	Response(ctx context.Context, c *Conn, req, resp *api.Message) error
+}
+
+// an error a handler can return to pick the InvalidRequest code sent back;
+// any other error is sent as UNKNOWN_ERROR
+type RequestError struct {
+	Code   api.InvalidRequest_Code
+	Reason string
+}
+
+func (e *RequestError) Error() string {
+	return e.Code.String() + ": " + e.Reason
+}
+
+var ErrServerClosed = errors.New("server closed")
+
+type Server struct {
+	// address to listen on; DefaultAddr if empty
+	Addr string
+	Auth AuthHandler
+	Game GameHandler
+	// optional
+	Middleware Middleware
+	// nil for the standard logger
+	Log *log.Logger
+	// framing used on every connection
+	Prefix       codec.Prefix
+	MaxFrameSize int
+	// how long shutting down waits for requests being handled to finish
+	// before closing their connections anyway; DefaultShutdownTimeout if 0
+	ShutdownTimeout time.Duration
+
+	// one for each connection's goroutine, which is handling at most one
+	// request at a time
+	wg sync.WaitGroup
+}
+
+// one client connection
+type Conn struct {
+	net.Conn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	codec  *codec.Codec
+}
+
+// cancelled when the connection is closed, or when the server shuts down and
+// the request being handled has run out of time to finish
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// sends a message to the client; handlers can use it to push notifications
+func (c *Conn) Send(m *api.Message) error {
+	return c.codec.Write(m)
+}
+
+func (s *Server) logf(format string, v ...interface{}) {
+	if s.Log != nil {
+		s.Log.Printf(format, v...)
+	} else {
+		log.Printf(format, v...)
+	}
+}
+
+// listens on s.Addr and serves until ctx is cancelled, then shuts down
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	addr := s.Addr
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+func (s *Server) shutdownTimeout() time.Duration {
+	if s.ShutdownTimeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+	return s.ShutdownTimeout
+}
+
+// accepts connections on ln, each handled in its own goroutine, until ctx is
+// cancelled. It then closes the listener, stops reading requests, and gives
+// the ones being handled up to ShutdownTimeout to be answered before closing
+// every connection and returning ErrServerClosed. Temporary errors from
+// Accept, like running out of file descriptors, are retried with a backoff
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+	// connections outlive ctx until their requests are done
+	connCtx, closeConns := context.WithCancel(context.Background())
+	defer closeConns()
+
+	var err error
+	var delay time.Duration
+	for {
+		var nc net.Conn
+		nc, err = ln.Accept()
+		if ne, ok := err.(net.Error); ok && ne.Temporary() && ctx.Err() == nil {
+			if delay == 0 {
+				delay = 5 * time.Millisecond
+			} else if delay *= 2; delay > time.Second {
+				delay = time.Second
+			}
+			s.logf("accept: %s; retrying in %s", err, delay)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+			}
+			continue
+		} else if err != nil {
+			break
+		}
+		delay = 0
+		cctx, ccancel := context.WithCancel(connCtx)
+		c := &Conn{Conn: nc, ctx: cctx, cancel: ccancel, codec: codec.New(nc, s.Prefix)}
+		c.codec.MaxFrameSize = s.MaxFrameSize
+		s.wg.Add(1)
+		go s.serveConn(ctx, c)
+	}
+
+	closed := ctx.Err() != nil
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	t := time.NewTimer(s.shutdownTimeout())
+	defer t.Stop()
+	select {
+	case <-done:
+	case <-t.C:
+		s.logf("requests still being handled after %s; closing their connections", s.shutdownTimeout())
+		closeConns()
+		<-done
+	}
+	if closed {
+		return ErrServerClosed
+	}
+	return err
+}
+
+// serves requests on c until it's closed, or until the server's ctx is
+// cancelled and the request being handled, if any, has been answered
+func (s *Server) serveConn(ctx context.Context, c *Conn) {
+	defer s.wg.Done()
+	defer c.cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+			// unblock the read below, leaving the connection open for the
+			// reply to a request that's still being handled
+			c.SetReadDeadline(time.Now())
+			<-c.ctx.Done()
+		case <-c.ctx.Done():
+		}
+		c.Close()
+	}()
+
+	for {
+		var resp *api.Message
+		m, err := c.codec.Read()
+		if ctx.Err() != nil && err != nil {
+			return
+		}
+		if ir := codec.Invalid(err); ir != nil {
+			resp = &api.Message{
+				Header:  &api.Header{Version: Version},
+				Payload: &api.Message_InvalidReq{InvalidReq: ir},
+			}
+		} else if err != nil {
+			if err != io.EOF && c.ctx.Err() == nil {
+				s.logf("%s: %s", c.RemoteAddr(), err)
+			}
+			return
+		} else {
+			resp = s.handle(c, m)
+			if c.ctx.Err() != nil {
+				// gave up on the connection while handling it
+				return
+			}
+		}
+		if err := c.Send(resp); err != nil {
+			if c.ctx.Err() == nil {
+				s.logf("%s: %s", c.RemoteAddr(), err)
+			}
+			return
+		}
+		if err == codec.ErrFrameSize || err == codec.ErrTruncated {
+			// the stream is out of sync
+			return
+		}
+	}
+}
+
+func invalid(code api.InvalidRequest_Code, reason string) *api.Message_InvalidReq {
+	return &api.Message_InvalidReq{InvalidReq: &api.InvalidRequest{Code: code, Reason: reason}}
+}
+
+type headerKey struct{}
+
+// the header of the request being handled
+func Header(ctx context.Context) *api.Header {
+	h, _ := ctx.Value(headerKey{}).(*api.Header)
+	return h
+}
+
+// works out the reply to one request
+func (s *Server) handle(c *Conn, m *api.Message) *api.Message {
+	resp := &api.Message{Header: &api.Header{Version: Version}}
+	ctx := c.ctx
+	err := func() error {
+		if m.Header == nil {
+			return &RequestError{api.InvalidRequest_MALFORMED_REQUEST, "missing header"}
+		}
+		resp.Header.ReqId = m.Header.ReqId
+		if m.Header.Version != Version {
+			return &RequestError{api.InvalidRequest_INCOMPATIBLE, "unsupported version"}
+		}
+		ctx = context.WithValue(ctx, headerKey{}, m.Header)
+		if s.Middleware != nil {
+			var err error
+			if ctx, err = s.Middleware.Request(ctx, c, m); err != nil {
+				return err
+			}
+		}
+		return s.dispatch(ctx, c, m, resp)
+	}()
+	if err != nil {
+		s.setError(c, resp, err)
+	}
+	if s.Middleware != nil {
+		if err := s.Middleware.Response(ctx, c, m, resp); err != nil {
+			s.setError(c, resp, err)
+		}
+	}
+	return resp
+}
+
+func (s *Server) setError(c *Conn, resp *api.Message, err error) {
+	if re, ok := err.(*RequestError); ok {
+		resp.Payload = invalid(re.Code, re.Reason)
+	} else {
+		s.logf("%s: %s", c.RemoteAddr(), err)
+		resp.Payload = invalid(api.InvalidRequest_UNKNOWN_ERROR, "internal error")
+	}
+}
+
+// passes the payload to its handler, filling in resp
+func (s *Server) dispatch(ctx context.Context, c *Conn, m *api.Message, resp *api.Message) error {
+	switch p := m.Payload.(type) {
+	case *api.Message_AuthReq:
+		if s.Auth == nil {
+			return &RequestError{api.InvalidRequest_UNKNOWN_ERROR, "auth requests not supported"}
+		}
+		ar, err := s.Auth.HandleAuth(ctx, c, p.AuthReq)
+		if err != nil {
+			return err
+		}
+		resp.Payload = &api.Message_AuthResp{AuthResp: ar}
+	case *api.Message_GameReq:
+		if s.Game == nil {
+			return &RequestError{api.InvalidRequest_UNKNOWN_ERROR, "game requests not supported"}
+		}
+		gr, err := s.Game.HandleGame(ctx, c, p.GameReq)
+		if err != nil {
+			return err
+		}
+		resp.Payload = &api.Message_GameResp{GameResp: gr}
+	case *api.Message_EncPayload:
+		return &RequestError{api.InvalidRequest_MALFORMED_REQUEST, "encrypted payloads not supported"}
+	case *api.Message_AuthResp, *api.Message_GameResp, *api.Message_InvalidReq:
+		return &RequestError{api.InvalidRequest_WAS_RESPONSE, "expected a request"}
+	default:
+		return &RequestError{api.InvalidRequest_MALFORMED_REQUEST, "missing payload"}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	api "github.com/cactorium/chesster-server/api"
+	codec "github.com/cactorium/chesster-server/codec"
+)
+
+type testHandler struct{}
+
+func (testHandler) HandleAuth(ctx context.Context, c *Conn, req *api.AuthRequest) (*api.AuthResponse, error) {
+	if req.GetAuth() == nil {
+		return nil, &RequestError{api.InvalidRequest_AUTH_ERROR, "no"}
+	}
+	return &api.AuthResponse{R: &api.AuthResponse_Auth{Auth: &api.Auth1Response{Token: []byte("token")}}}, nil
+}
+
+func (testHandler) HandleGame(ctx context.Context, c *Conn, req *api.GameRequest) (*api.GameResponse, error) {
+	if len(req.Gs) == 0 {
+		return nil, errors.New("broken")
+	}
+	return &api.GameResponse{Gs: []*api.GameResp{{GameId: req.Gs[0].GameId}}}, nil
+}
+
+// starts a server on a local port; cancel stops it and returns Serve's error
+func startServer(t *testing.T) (net.Addr, func() error) {
+	return start(t, &Server{Auth: testHandler{}, Game: testHandler{}}, nil)
+}
+
+// starts s on ln, or a local port if ln is nil
+func start(t *testing.T, s *Server, ln net.Listener) (net.Addr, func() error) {
+	if ln == nil {
+		var err error
+		if ln, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx, ln)
+	}()
+	return ln.Addr(), func() error {
+		cancel()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			return errors.New("server didn't shut down")
+		}
+	}
+}
+
+func roundTrip(t *testing.T, c *codec.Codec, m *api.Message) *api.Message {
+	if err := c.Write(m); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestServer(t *testing.T) {
+	addr, stop := startServer(t)
+	nc, err := net.Dial("tcp", addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	c := codec.New(nc, codec.Uint32)
+
+	header := func(id uint32) *api.Header {
+		return &api.Header{ReqId: id}
+	}
+	authReq := &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_Auth{Auth: &api.Auth1Request{UserId: "a"}}}}
+	gameReq := &api.Message_GameReq{GameReq: &api.GameRequest{Gs: []*api.GameReq{{GameId: []byte("g")}}}}
+
+	resp := roundTrip(t, c, &api.Message{Header: header(1), Payload: authReq})
+	if string(resp.GetAuthResp().GetAuth().GetToken()) != "token" || resp.Header.ReqId != 1 {
+		t.Errorf("bad auth response: %v", resp)
+	}
+	resp = roundTrip(t, c, &api.Message{Header: header(2), Payload: gameReq})
+	if gs := resp.GetGameResp().GetGs(); len(gs) != 1 || string(gs[0].GameId) != "g" || resp.Header.ReqId != 2 {
+		t.Errorf("bad game response: %v", resp)
+	}
+
+	cases := []struct {
+		m    *api.Message
+		code api.InvalidRequest_Code
+	}{
+		{&api.Message{Header: header(3), Payload: &api.Message_AuthReq{AuthReq: &api.AuthRequest{}}}, api.InvalidRequest_AUTH_ERROR},
+		{&api.Message{Header: header(4), Payload: &api.Message_GameReq{GameReq: &api.GameRequest{}}}, api.InvalidRequest_UNKNOWN_ERROR},
+		{&api.Message{Header: header(5), Payload: &api.Message_GameResp{GameResp: &api.GameResponse{}}}, api.InvalidRequest_WAS_RESPONSE},
+		{&api.Message{Header: header(6)}, api.InvalidRequest_MALFORMED_REQUEST},
+		{&api.Message{Payload: authReq}, api.InvalidRequest_MALFORMED_REQUEST},
+		{&api.Message{Header: &api.Header{Version: 1, ReqId: 7}, Payload: authReq}, api.InvalidRequest_INCOMPATIBLE},
+	}
+	for i, cs := range cases {
+		resp := roundTrip(t, c, cs.m)
+		if resp.GetInvalidReq() == nil || resp.GetInvalidReq().Code != cs.code {
+			t.Errorf("case %d: expected %s got %v", i, cs.code, resp)
+		}
+		if resp.Header.ReqId != cs.m.GetHeader().GetReqId() {
+			t.Errorf("case %d: expected req_id %d got %d", i, cs.m.GetHeader().GetReqId(), resp.Header.ReqId)
+		}
+	}
+
+	if err := stop(); err != ErrServerClosed {
+		t.Errorf("expected %v got %v", ErrServerClosed, err)
+	}
+	// the connection gets closed on shutdown
+	nc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c.Read(); err == nil {
+		t.Errorf("connection still open after shutdown")
+	}
+}
+
+func TestServerBadFrames(t *testing.T) {
+	addr, stop := startServer(t)
+	defer stop()
+	nc, err := net.Dial("tcp", addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	c := codec.New(nc, codec.Uint32)
+
+	// not a protobuf; the connection stays open afterwards
+	if err := c.WriteFrame([]byte{0xff, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := c.Read(); err != nil || resp.GetInvalidReq().GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+		t.Errorf("expected %s got %v (%v)", api.InvalidRequest_MALFORMED_REQUEST, resp, err)
+	}
+
+	// too large; the server gives up on the connection
+	if _, err := nc.Write([]byte{0xff, 0xff, 0xff, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := c.Read(); err != nil || resp.GetInvalidReq().GetCode() != api.InvalidRequest_MALFORMED_REQUEST {
+		t.Errorf("expected %s got %v (%v)", api.InvalidRequest_MALFORMED_REQUEST, resp, err)
+	}
+	nc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c.Read(); err == nil {
+		t.Errorf("connection still open after oversize frame")
+	}
+}
+
+// holds up game requests until released, or until the request's context is
+// cancelled
+type slowHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h slowHandler) HandleGame(ctx context.Context, c *Conn, req *api.GameRequest) (*api.GameResponse, error) {
+	h.started <- struct{}{}
+	select {
+	case <-h.release:
+		return &api.GameResponse{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func dialSlow(t *testing.T, s *Server) (*codec.Codec, net.Conn, slowHandler, func() error) {
+	h := slowHandler{started: make(chan struct{}, 1), release: make(chan struct{})}
+	s.Game = h
+	addr, stop := start(t, s, nil)
+	nc, err := net.Dial("tcp", addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := codec.New(nc, codec.Uint32)
+	gameReq := &api.Message_GameReq{GameReq: &api.GameRequest{}}
+	if err := c.Write(&api.Message{Header: &api.Header{ReqId: 1}, Payload: gameReq}); err != nil {
+		t.Fatal(err)
+	}
+	<-h.started
+	return c, nc, h, stop
+}
+
+func TestServerDrain(t *testing.T) {
+	c, nc, h, stop := dialSlow(t, &Server{})
+	defer nc.Close()
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- stop()
+	}()
+	select {
+	case err := <-stopped:
+		t.Fatalf("shut down with a request still being handled: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the request being handled still gets its reply, then the connection's
+	// closed
+	close(h.release)
+	nc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if resp, err := c.Read(); err != nil || resp.GetGameResp() == nil {
+		t.Errorf("expected a game response got %v, %v", resp, err)
+	}
+	if err := <-stopped; err != ErrServerClosed {
+		t.Errorf("expected %v got %v", ErrServerClosed, err)
+	}
+	if _, err := c.Read(); err == nil {
+		t.Errorf("connection still open after shutdown")
+	}
+}
+
+func TestServerDrainTimeout(t *testing.T) {
+	c, nc, _, stop := dialSlow(t, &Server{ShutdownTimeout: 50 * time.Millisecond})
+	defer nc.Close()
+
+	if err := stop(); err != ErrServerClosed {
+		t.Errorf("expected %v got %v", ErrServerClosed, err)
+	}
+	nc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if resp, err := c.Read(); err == nil {
+		t.Errorf("connection still open after the shutdown timeout: %v", resp)
+	}
+}
+
+type tempError struct{}
+
+func (tempError) Error() string   { return "too many open files" }
+func (tempError) Timeout() bool   { return false }
+func (tempError) Temporary() bool { return true }
+
+// fails the first few calls to Accept with a temporary error
+type flakyListener struct {
+	net.Listener
+	fails int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.fails > 0 {
+		l.fails--
+		return nil, tempError{}
+	}
+	return l.Listener.Accept()
+}
+
+func TestServerAcceptRetry(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{Auth: testHandler{}, Log: log.New(ioutil.Discard, "", 0)}
+	addr, stop := start(t, s, &flakyListener{Listener: ln, fails: 3})
+	defer stop()
+	nc, err := net.Dial("tcp", addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	nc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	authReq := &api.Message_AuthReq{AuthReq: &api.AuthRequest{R: &api.AuthRequest_Auth{Auth: &api.Auth1Request{UserId: "a"}}}}
+	if resp := roundTrip(t, codec.New(nc, codec.Uint32), &api.Message{Header: &api.Header{ReqId: 1}, Payload: authReq}); resp.GetAuthResp() == nil {
+		t.Errorf("expected an auth response got %v", resp)
+	}
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+// LoadGame rebuilds a stored game by replaying its moves from its starting
+// position, checking each one still leads to the position stored with it and
+// that the game ends up matching its snapshot. If they don't it returns a
+// *chesster.HistoryError saying where, along with the game as far as it
+// could be replayed.
+func LoadGame(s GameStore, id []byte) (chesster.Game, Game, error) {
+	g, err := s.Game(id)
+	if err != nil {
+		return chesster.Game{}, Game{}, err
+	}
+	ms, err := s.Moves(id)
+	if err != nil {
+		return chesster.Game{}, g, err
+	}
+	start := chesster.NewGame()
+	if g.StartFEN != "" {
+		if start, err = chesster.ParseFEN(g.StartFEN); err != nil {
+			return chesster.Game{}, g, err
+		}
+	}
+	hms := make([]chesster.HistoryMove, len(ms))
+	for i, m := range ms {
+		hms[i] = chesster.HistoryMove{UCI: m.UCI, FEN: m.FEN}
+	}
+	cg, err := chesster.ReplayHistory(start, hms, g.Snapshot)
+	return cg, g, err
+}
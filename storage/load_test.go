@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+// plays moves in a stored game the way the server would, returning the game
+// as it should be after them
+func play(t *testing.T, s GameStore, id []byte, start chesster.Game, ucis ...string) chesster.Game {
+	g := start.Clone()
+	for _, uci := range ucis {
+		m, err := chesster.ParseUCI(&g.Board, uci)
+		if err != nil {
+			t.Fatalf("%s: %s", uci, err)
+		}
+		if ok, r := g.DoMove(m); !ok {
+			t.Fatalf("%s: move rejected: %s", uci, r)
+		}
+		mv := Move{Ply: len(g.Moves) - 1, UCI: uci, Played: time.Now()}
+		if err := s.AddMove(id, mv, g.Snapshot()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestLoadGame(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		start := chesster.NewGame()
+		if err := s.CreateGame(Game{ID: []byte("g1"), White: "alice", Black: "bob", Snapshot: start.Snapshot(), Started: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := LoadGame(s, []byte("g1")); err != nil {
+			t.Errorf("no moves: %v", err)
+		}
+		want := play(t, s, []byte("g1"), start, "e2e4", "e7e5", "d1h5", "b8c6", "f1c4", "g8f6", "h5f7")
+		g, stored, err := LoadGame(s, []byte("g1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.ToFEN() != want.ToFEN() || g.State != chesster.WhiteCheckmate || stored.State != chesster.WhiteCheckmate {
+			t.Errorf("expected %s checkmated got %s %s", want.ToFEN(), g.ToFEN(), g.State)
+		}
+
+		if _, _, err := LoadGame(s, []byte("nope")); err != ErrNoGame {
+			t.Errorf("expected %v got %v", ErrNoGame, err)
+		}
+
+		// a game that was resigned replays to the position it was resigned in
+		if err := s.CreateGame(Game{ID: []byte("g2"), White: "alice", Black: "bob", Snapshot: start.Snapshot(), Started: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+		play(t, s, []byte("g2"), start, "d2d4")
+		if err := s.FinishGame([]byte("g2"), chesster.BlackResigned, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if g, _, err := LoadGame(s, []byte("g2")); err != nil || g.State != chesster.BlackResigned {
+			t.Errorf("expected black resigned got %s, %v", g.State, err)
+		}
+	})
+}
+
+func TestLoadGameDiverged(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		start, err := chesster.ParseFEN("4k3/8/8/8/8/8/4P3/4K3 w - - 0 1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := Game{ID: []byte("g1"), White: "alice", Black: "bob", StartFEN: start.ToFEN(), Snapshot: start.Snapshot(), Started: time.Now()}
+		if err := s.CreateGame(g); err != nil {
+			t.Fatal(err)
+		}
+		after := play(t, s, []byte("g1"), start, "e2e4", "e8d7")
+
+		// stored as if the king had gone the other way
+		wrong := after.Snapshot()
+		wrong.FEN = "8/4k3/8/8/4P3/8/8/4K3 w - - 1 2"
+		wrong.MovesSinceCapture = 3
+		if err := s.AddMove([]byte("g1"), Move{Ply: 2, UCI: "e1e2", Played: time.Now()}, wrong); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = LoadGame(s, []byte("g1"))
+		he, ok := err.(*chesster.HistoryError)
+		if !ok {
+			t.Fatalf("expected a *chesster.HistoryError got %v", err)
+		}
+		if len(he.Divergences) != 3 {
+			t.Fatalf("expected the position twice and the capture count to diverge, got %s", he)
+		}
+		for i, f := range []string{"fen", "fen", "moves since capture"} {
+			if d := he.Divergences[i]; d.Ply != 3 || d.Field != f || d.Expected == d.Actual {
+				t.Errorf("expected a divergence at ply 3 in %s, got %s", f, d)
+			}
+		}
+	})
+}
+
+// games stored before snapshots were kept only have their results checked
+func TestLoadGameUpgraded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chesster.db")
+	db, err := openDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Migrator{db: db, migrations: migrations[:1]}
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{
+		"INSERT INTO games (id, white, black, start_fen, state, started) VALUES (X'6731', 'alice', 'bob', '', 0, 1)",
+		"INSERT INTO moves (game_id, ply, uci, played) VALUES (X'6731', 0, 'e2e4', 1), (X'6731', 1, 'e7e5', 2)",
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	m, err = NewMigrator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	g, _, err := LoadGame(s, []byte("g1"))
+	if err != nil || len(g.Moves) != 2 {
+		t.Errorf("expected 2 moves replayed got %d, %v", len(g.Moves), err)
+	}
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	auth "github.com/cactorium/chesster-server/auth"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+// a Store that forgets everything on restart; for tests
+type Memory struct {
+	*auth.MemoryUsers
+	*auth.MemoryKeys
+
+	mu       sync.Mutex
+	sessions map[string]auth.SavedSession
+	secrets  map[string][]byte
+	games    map[string]*memoryGame
+}
+
+type memoryGame struct {
+	Game
+	moves []Move
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		MemoryUsers: auth.NewMemoryUsers(),
+		MemoryKeys:  auth.NewMemoryKeys(),
+		sessions:    map[string]auth.SavedSession{},
+		secrets:     map[string][]byte{},
+		games:       map[string]*memoryGame{},
+	}
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
+
+func (m *Memory) SaveSession(s auth.SavedSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[string(s.Token)] = s
+	return nil
+}
+
+func (m *Memory) DeleteSession(token []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, string(token))
+	return nil
+}
+
+func (m *Memory) LoadSessions() ([]auth.SavedSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var ss []auth.SavedSession
+	for _, s := range m.sessions {
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+func (m *Memory) Secret(name string, secret []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if kept, ok := m.secrets[name]; ok {
+		return kept, nil
+	}
+	m.secrets[name] = secret
+	return secret, nil
+}
+
+func (m *Memory) CreateGame(g Game) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.games[string(g.ID)]; ok {
+		return ErrGameID
+	}
+	m.games[string(g.ID)] = &memoryGame{Game: g}
+	return nil
+}
+
+func (m *Memory) game(id []byte) (*memoryGame, error) {
+	g, ok := m.games[string(id)]
+	if !ok {
+		return nil, ErrNoGame
+	}
+	return g, nil
+}
+
+func (m *Memory) Game(id []byte) (Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g, err := m.game(id)
+	if err != nil {
+		return Game{}, err
+	}
+	return g.Game, nil
+}
+
+func (m *Memory) Moves(id []byte) ([]Move, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g, err := m.game(id)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Move(nil), g.moves...), nil
+}
+
+func (m *Memory) AddMove(id []byte, mv Move, after chesster.Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g, err := m.game(id)
+	if err != nil {
+		return err
+	}
+	if g.State != chesster.InPlay {
+		return ErrGameOver
+	}
+	if mv.Ply != len(g.moves) {
+		return ErrPly
+	}
+	mv.FEN = after.FEN
+	g.moves = append(g.moves, mv)
+	g.Snapshot = after
+	if after.State != chesster.InPlay {
+		g.Finished = mv.Played
+	}
+	return nil
+}
+
+func (m *Memory) FinishGame(id []byte, state chesster.GameState, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g, err := m.game(id)
+	if err != nil {
+		return err
+	}
+	if g.State != chesster.InPlay {
+		return ErrGameOver
+	}
+	g.State = state
+	g.Finished = at
+	return nil
+}
+
+// the games that match, sorted by when they started
+func (m *Memory) findGames(match func(Game) bool, newestFirst bool) []Game {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var gs []Game
+	for _, g := range m.games {
+		if match(g.Game) {
+			gs = append(gs, g.Game)
+		}
+	}
+	sort.Slice(gs, func(i, j int) bool {
+		if newestFirst {
+			i, j = j, i
+		}
+		return gs[i].Started.Before(gs[j].Started)
+	})
+	return gs
+}
+
+func (m *Memory) ActiveGames() ([]Game, error) {
+	return m.findGames(func(g Game) bool { return g.State == chesster.InPlay }, false), nil
+}
+
+func (m *Memory) PlayerGames(user string) ([]Game, error) {
+	return m.findGames(func(g Game) bool { return g.White == user || g.Black == user }, true), nil
+}
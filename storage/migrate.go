@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNewerSchema = errors.New("database schema is newer than this build; upgrade chessterd")
+	ErrOlderSchema = errors.New("database schema is out of date; run chessterd migrate up")
+)
+
+// one step in the schema's history, from migrations/NNNN_name.sql
+type Migration struct {
+	// the schema version it takes the database to; the one before it is
+	// Version-1
+	Version int
+	Name    string
+	SQL     string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// migrations are forward only, and once released are never changed; new ones
+// go on the end
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrations = mustLoadMigrations(migrationFiles)
+
+// reads the migrations in dir "migrations", checking they're numbered 1, 2, ...
+// with nothing missing
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	var ms []Migration
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".sql")
+		i := strings.IndexByte(name, '_')
+		if i < 0 {
+			return nil, fmt.Errorf("migration %s: expected NNNN_name.sql", e.Name())
+		}
+		v, err := strconv.Atoi(name[:i])
+		if err != nil || v != len(ms)+1 {
+			return nil, fmt.Errorf("migration %s: expected version %d", e.Name(), len(ms)+1)
+		}
+		sql, err := fs.ReadFile(fsys, path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, Migration{Version: v, Name: name[i+1:], SQL: string(sql)})
+	}
+	return ms, nil
+}
+
+func mustLoadMigrations(fsys fs.FS) []Migration {
+	ms, err := loadMigrations(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return ms
+}
+
+// looks after a database's schema version, which is kept in user_version
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// opens the database at path just for migrating it
+func NewMigrator(path string) (*Migrator, error) {
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// the version the database is at, 0 for a fresh one
+func (m *Migrator) Version() (int, error) {
+	var v int
+	err := m.db.QueryRow("PRAGMA user_version").Scan(&v)
+	return v, err
+}
+
+// the version this build's migrations go up to
+func (m *Migrator) Latest() int {
+	return len(m.migrations)
+}
+
+// the migrations the database still needs, or ErrNewerSchema if it's ahead of
+// this build
+func (m *Migrator) Pending() ([]Migration, error) {
+	v, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+	if v > len(m.migrations) {
+		return nil, ErrNewerSchema
+	}
+	return m.migrations[v:], nil
+}
+
+// runs the pending migrations in one transaction, so the database is either
+// brought all the way up or left alone. Unless commit is set, it's rolled
+// back afterwards anyway
+func (m *Migrator) run(commit bool) ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil || len(pending) == 0 {
+		return nil, err
+	}
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	for _, mg := range pending {
+		if _, err := tx.Exec(mg.SQL); err != nil {
+			return nil, fmt.Errorf("migration %s: %v", mg, err)
+		}
+		// can't be a parameter
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", mg.Version)); err != nil {
+			return nil, err
+		}
+	}
+	if !commit {
+		return pending, nil
+	}
+	return pending, tx.Commit()
+}
+
+// brings the database up to date, returning the migrations applied
+func (m *Migrator) Up() ([]Migration, error) {
+	return m.run(true)
+}
+
+// checks the pending migrations apply cleanly without keeping them
+func (m *Migrator) DryRun() ([]Migration, error) {
+	return m.run(false)
+}
+
+// what the server needs before starting: a fresh database gets the whole
+// schema, but one that's behind has to be migrated on purpose, and one that's
+// ahead can't be used at all
+func (m *Migrator) check() error {
+	v, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if v == 0 {
+		_, err := m.Up()
+		return err
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return ErrOlderSchema
+	}
+	return nil
+}
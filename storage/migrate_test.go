@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	if len(migrations) == 0 || migrations[0].Version != 1 || migrations[0].Name != "initial" {
+		t.Errorf("bad embedded migrations: %v", migrations)
+	}
+
+	file := func(sql string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(sql)}
+	}
+	ms, err := loadMigrations(fstest.MapFS{
+		"migrations/0002_more.sql":  file("B"),
+		"migrations/0001_first.sql": file("A"),
+	})
+	if err != nil || len(ms) != 2 || ms[0].String() != "0001_first" || ms[1].SQL != "B" {
+		t.Errorf("expected 0001_first, 0002_more got %v, %v", ms, err)
+	}
+	for name, fsys := range map[string]fstest.MapFS{
+		"gap":       {"migrations/0001_a.sql": file(""), "migrations/0003_c.sql": file("")},
+		"no name":   {"migrations/0001.sql": file("")},
+		"duplicate": {"migrations/0001_a.sql": file(""), "migrations/0001_b.sql": file("")},
+		"from 0":    {"migrations/0000_a.sql": file("")},
+	} {
+		if _, err := loadMigrations(fsys); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// a migrator for the database at path with an extra migration on the end
+func migratorWith(t *testing.T, path string, extra string) *Migrator {
+	db, err := openDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	ms := append([]Migration{}, migrations...)
+	ms = append(ms, Migration{Version: len(ms) + 1, Name: "extra", SQL: extra})
+	return &Migrator{db: db, migrations: ms}
+}
+
+func TestMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chesster.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	m := migratorWith(t, path, "CREATE TABLE extra (x INTEGER); INSERT INTO extra VALUES (1);")
+	if err := m.check(); err != ErrOlderSchema {
+		t.Errorf("expected %v got %v", ErrOlderSchema, err)
+	}
+	ms, err := m.DryRun()
+	if err != nil || len(ms) != 1 || ms[0].Name != "extra" {
+		t.Errorf("dry run: expected extra got %v, %v", ms, err)
+	}
+	if v, _ := m.Version(); v != len(migrations) {
+		t.Errorf("dry run changed the version to %d", v)
+	}
+	if ms, err := m.Up(); err != nil || len(ms) != 1 {
+		t.Fatalf("expected extra applied got %v, %v", ms, err)
+	}
+	var x int
+	if err := m.db.QueryRow("SELECT x FROM extra").Scan(&x); err != nil || x != 1 {
+		t.Errorf("migration not applied: %d, %v", x, err)
+	}
+	if v, _ := m.Version(); v != m.Latest() {
+		t.Errorf("expected version %d got %d", m.Latest(), v)
+	}
+	if ms, err := m.Up(); err != nil || len(ms) != 0 {
+		t.Errorf("second up: expected nothing applied got %v, %v", ms, err)
+	}
+
+	// this build's migrations are behind the database now
+	if _, err := Open(path); err != ErrNewerSchema {
+		t.Errorf("expected %v got %v", ErrNewerSchema, err)
+	}
+}
+
+func TestMigrateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chesster.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// the table gets created before the failure, but has to be rolled back
+	m := migratorWith(t, path, "CREATE TABLE extra (x INTEGER); CREATE TABLE users (x INTEGER);")
+	if _, err := m.Up(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if v, _ := m.Version(); v != len(migrations) {
+		t.Errorf("failed migration changed the version to %d", v)
+	}
+	if _, err := m.db.Exec("SELECT * FROM extra"); err == nil {
+		t.Errorf("failed migration left a table behind")
+	}
+}
+
+func TestOpenFresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chesster.db")
+	m, err := NewMigrator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if v, err := m.Version(); err != nil || v != 0 {
+		t.Errorf("expected version 0 got %d, %v", v, err)
+	}
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if v, err := m.Version(); err != nil || v != m.Latest() {
+		t.Errorf("fresh database: expected version %d got %d, %v", m.Latest(), v, err)
+	}
+}
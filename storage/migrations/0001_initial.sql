@@ -0,0 +1,41 @@
+-- everything from the README's architecture
+CREATE TABLE users (
+	name TEXT PRIMARY KEY,
+	salt BLOB NOT NULL,
+	password_hash BLOB NOT NULL
+);
+
+CREATE TABLE device_keys (
+	pub BLOB PRIMARY KEY,
+	user TEXT NOT NULL REFERENCES users(name),
+	device_type BLOB
+);
+CREATE INDEX device_keys_user ON device_keys(user);
+
+CREATE TABLE sessions (
+	token BLOB PRIMARY KEY,
+	user TEXT NOT NULL,
+	expiry INTEGER NOT NULL,
+	state BLOB NOT NULL
+);
+
+CREATE TABLE games (
+	id BLOB PRIMARY KEY,
+	white TEXT NOT NULL,
+	black TEXT NOT NULL,
+	start_fen TEXT NOT NULL,
+	state INTEGER NOT NULL,
+	started INTEGER NOT NULL,
+	finished INTEGER
+);
+CREATE INDEX games_white ON games(white, started);
+CREATE INDEX games_black ON games(black, started);
+CREATE INDEX games_state ON games(state, started);
+
+CREATE TABLE moves (
+	game_id BLOB NOT NULL REFERENCES games(id),
+	ply INTEGER NOT NULL,
+	uci TEXT NOT NULL,
+	played INTEGER NOT NULL,
+	PRIMARY KEY (game_id, ply)
+);
@@ -0,0 +1,8 @@
+-- the position after each move, and where each game is now, so loading a
+-- game can check its moves still lead there
+ALTER TABLE games ADD COLUMN fen TEXT NOT NULL DEFAULT '';
+ALTER TABLE games ADD COLUMN white_check INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN black_check INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN moves_since_capture INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE moves ADD COLUMN fen TEXT NOT NULL DEFAULT '';
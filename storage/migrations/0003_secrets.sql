@@ -0,0 +1,6 @@
+-- the server's own secrets, like the one salts for users that don't exist are
+-- made up from, so they stay the same after a restart
+CREATE TABLE secrets (
+	name TEXT PRIMARY KEY,
+	value BLOB NOT NULL
+);
@@ -0,0 +1,340 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	// registers the "sqlite3" driver
+	_ "github.com/mattn/go-sqlite3"
+
+	auth "github.com/cactorium/chesster-server/auth"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+// a Store in an SQLite database
+type SQLite struct {
+	db *sql.DB
+}
+
+func openDB(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only has one writer at a time anyway, and ":memory:" databases
+	// are per connection
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// opens the database at path, creating it with the latest schema if needed.
+// A database with an older schema has to be migrated first, and fails with
+// ErrOlderSchema; one with a newer schema fails with ErrNewerSchema
+func Open(path string) (*SQLite, error) {
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Migrator{db: db, migrations: migrations}
+	if err := m.check(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLite{db: db}, nil
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// times are kept as Unix nanoseconds, with the zero time as NULL
+func toUnix(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.UnixNano(), Valid: true}
+}
+
+func fromUnix(n sql.NullInt64) time.Time {
+	if !n.Valid {
+		return time.Time{}
+	}
+	return time.Unix(0, n.Int64)
+}
+
+// runs f in a transaction, committing if it doesn't fail
+func (s *SQLite) inTx(f func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// whether a query matches any rows
+func exists(tx *sql.Tx, query string, args ...interface{}) (bool, error) {
+	var one int
+	err := tx.QueryRow(query, args...).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *SQLite) User(name string) (auth.User, error) {
+	u := auth.User{Name: name}
+	err := s.db.QueryRow("SELECT salt, password_hash FROM users WHERE name = ?", name).Scan(&u.Salt, &u.PasswordHash)
+	if err == sql.ErrNoRows {
+		return auth.User{}, auth.ErrNoUser
+	}
+	return u, err
+}
+
+func (s *SQLite) Add(u auth.User) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		if ok, err := exists(tx, "SELECT 1 FROM users WHERE name = ?", u.Name); err != nil {
+			return err
+		} else if ok {
+			return auth.ErrUserExists
+		}
+		_, err := tx.Exec("INSERT INTO users (name, salt, password_hash) VALUES (?, ?, ?)", u.Name, u.Salt, u.PasswordHash)
+		return err
+	})
+}
+
+func (s *SQLite) Key(user string, pub []byte) (auth.DeviceKey, error) {
+	k := auth.DeviceKey{User: user, Pub: pub}
+	err := s.db.QueryRow("SELECT device_type FROM device_keys WHERE pub = ? AND user = ?", pub, user).Scan(&k.DeviceType)
+	if err == sql.ErrNoRows {
+		return auth.DeviceKey{}, auth.ErrNoKey
+	}
+	return k, err
+}
+
+func (s *SQLite) AddKey(k auth.DeviceKey) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		if ok, err := exists(tx, "SELECT 1 FROM device_keys WHERE pub = ?", k.Pub); err != nil {
+			return err
+		} else if ok {
+			return auth.ErrKeyExists
+		}
+		_, err := tx.Exec("INSERT INTO device_keys (pub, user, device_type) VALUES (?, ?, ?)", k.Pub, k.User, k.DeviceType)
+		return err
+	})
+}
+
+func (s *SQLite) Keys(user string) ([]auth.DeviceKey, error) {
+	rows, err := s.db.Query("SELECT pub, device_type FROM device_keys WHERE user = ? ORDER BY pub", user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ks []auth.DeviceKey
+	for rows.Next() {
+		k := auth.DeviceKey{User: user}
+		if err := rows.Scan(&k.Pub, &k.DeviceType); err != nil {
+			return nil, err
+		}
+		ks = append(ks, k)
+	}
+	return ks, rows.Err()
+}
+
+func (s *SQLite) RevokeKey(user string, pub []byte) error {
+	r, err := s.db.Exec("DELETE FROM device_keys WHERE pub = ? AND user = ?", pub, user)
+	if err != nil {
+		return err
+	}
+	if n, err := r.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return auth.ErrNoKey
+	}
+	return nil
+}
+
+func (s *SQLite) SaveSession(ss auth.SavedSession) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO sessions (token, user, expiry, state) VALUES (?, ?, ?, ?)",
+		ss.Token, ss.User, toUnix(ss.Expiry), ss.State)
+	return err
+}
+
+func (s *SQLite) DeleteSession(token []byte) error {
+	_, err := s.db.Exec("DELETE FROM sessions WHERE token = ?", token)
+	return err
+}
+
+func (s *SQLite) LoadSessions() ([]auth.SavedSession, error) {
+	rows, err := s.db.Query("SELECT token, user, expiry, state FROM sessions")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ss []auth.SavedSession
+	for rows.Next() {
+		var sess auth.SavedSession
+		var expiry sql.NullInt64
+		if err := rows.Scan(&sess.Token, &sess.User, &expiry, &sess.State); err != nil {
+			return nil, err
+		}
+		sess.Expiry = fromUnix(expiry)
+		ss = append(ss, sess)
+	}
+	return ss, rows.Err()
+}
+
+func (s *SQLite) Secret(name string, secret []byte) ([]byte, error) {
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO secrets (name, value) VALUES (?, ?)", name, secret); err != nil {
+		return nil, err
+	}
+	var kept []byte
+	err := s.db.QueryRow("SELECT value FROM secrets WHERE name = ?", name).Scan(&kept)
+	return kept, err
+}
+
+func (s *SQLite) CreateGame(g Game) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		if ok, err := exists(tx, "SELECT 1 FROM games WHERE id = ?", g.ID); err != nil {
+			return err
+		} else if ok {
+			return ErrGameID
+		}
+		_, err := tx.Exec("INSERT INTO games ("+gameColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			g.ID, g.White, g.Black, g.StartFEN, int(g.State), toUnix(g.Started), toUnix(g.Finished),
+			g.FEN, g.WhiteCheck, g.BlackCheck, g.MovesSinceCapture)
+		return err
+	})
+}
+
+const gameColumns = "id, white, black, start_fen, state, started, finished, fen, white_check, black_check, moves_since_capture"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanGame(r scanner) (Game, error) {
+	var g Game
+	var state int
+	var started, finished sql.NullInt64
+	if err := r.Scan(&g.ID, &g.White, &g.Black, &g.StartFEN, &state, &started, &finished,
+		&g.FEN, &g.WhiteCheck, &g.BlackCheck, &g.MovesSinceCapture); err != nil {
+		return Game{}, err
+	}
+	g.State = chesster.GameState(state)
+	g.Started = fromUnix(started)
+	g.Finished = fromUnix(finished)
+	return g, nil
+}
+
+func (s *SQLite) Game(id []byte) (Game, error) {
+	g, err := scanGame(s.db.QueryRow("SELECT "+gameColumns+" FROM games WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return Game{}, ErrNoGame
+	}
+	return g, err
+}
+
+func (s *SQLite) games(query string, args ...interface{}) ([]Game, error) {
+	rows, err := s.db.Query("SELECT "+gameColumns+" FROM games "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var gs []Game
+	for rows.Next() {
+		g, err := scanGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		gs = append(gs, g)
+	}
+	return gs, rows.Err()
+}
+
+func (s *SQLite) ActiveGames() ([]Game, error) {
+	return s.games("WHERE state = ? ORDER BY started", int(chesster.InPlay))
+}
+
+func (s *SQLite) PlayerGames(user string) ([]Game, error) {
+	return s.games("WHERE white = ? OR black = ? ORDER BY started DESC", user, user)
+}
+
+func (s *SQLite) Moves(id []byte) ([]Move, error) {
+	if _, err := s.Game(id); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query("SELECT ply, uci, fen, played FROM moves WHERE game_id = ? ORDER BY ply", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ms []Move
+	for rows.Next() {
+		var m Move
+		var played sql.NullInt64
+		if err := rows.Scan(&m.Ply, &m.UCI, &m.FEN, &played); err != nil {
+			return nil, err
+		}
+		m.Played = fromUnix(played)
+		ms = append(ms, m)
+	}
+	return ms, rows.Err()
+}
+
+// the state of a game for changing it, or ErrNoGame
+func gameState(tx *sql.Tx, id []byte) (chesster.GameState, error) {
+	var state int
+	err := tx.QueryRow("SELECT state FROM games WHERE id = ?", id).Scan(&state)
+	if err == sql.ErrNoRows {
+		return 0, ErrNoGame
+	}
+	return chesster.GameState(state), err
+}
+
+func (s *SQLite) AddMove(id []byte, m Move, after chesster.Snapshot) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		if state, err := gameState(tx, id); err != nil {
+			return err
+		} else if state != chesster.InPlay {
+			return ErrGameOver
+		}
+		var n int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM moves WHERE game_id = ?", id).Scan(&n); err != nil {
+			return err
+		}
+		if m.Ply != n {
+			return ErrPly
+		}
+		if _, err := tx.Exec("INSERT INTO moves (game_id, ply, uci, fen, played) VALUES (?, ?, ?, ?, ?)",
+			id, m.Ply, m.UCI, after.FEN, toUnix(m.Played)); err != nil {
+			return err
+		}
+		var finished time.Time
+		if after.State != chesster.InPlay {
+			finished = m.Played
+		}
+		_, err := tx.Exec("UPDATE games SET fen = ?, state = ?, white_check = ?, black_check = ?, moves_since_capture = ?, finished = ? WHERE id = ?",
+			after.FEN, int(after.State), after.WhiteCheck, after.BlackCheck, after.MovesSinceCapture, toUnix(finished), id)
+		return err
+	})
+}
+
+func (s *SQLite) FinishGame(id []byte, state chesster.GameState, at time.Time) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		if old, err := gameState(tx, id); err != nil {
+			return err
+		} else if old != chesster.InPlay {
+			return ErrGameOver
+		}
+		_, err := tx.Exec("UPDATE games SET state = ?, finished = ? WHERE id = ?", int(state), toUnix(at), id)
+		return err
+	})
+}
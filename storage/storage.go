@@ -0,0 +1,86 @@
+// Package storage keeps what has to survive a restart: users and their device
+// keys, sessions, the server's secret, and games with their moves and results.
+//
+// Store is what the rest of the server uses. Open gives one backed by an
+// SQLite database, and NewMemory one that forgets everything, for tests.
+package storage
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+
+	auth "github.com/cactorium/chesster-server/auth"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+var (
+	ErrNoGame   = errors.New("no such game")
+	ErrGameOver = errors.New("game already finished")
+	ErrPly      = errors.New("move isn't the next one in the game")
+	ErrGameID   = errors.New("game id already used")
+)
+
+const GameIDSize = 16
+
+type Game struct {
+	ID    []byte
+	White string
+	Black string
+	// FEN of the starting position; empty for the usual one
+	StartFEN string
+	// where the game is now, as of its last move; State is its result once
+	// it's over. The FEN is empty until the first move if the game was
+	// created without one
+	chesster.Snapshot
+	Started time.Time
+	// zero until the game's over
+	Finished time.Time
+}
+
+// one half move
+type Move struct {
+	// counting from 0 for the game's first move
+	Ply int
+	// in the long algebraic notation from chesster's Move.UCI
+	UCI string
+	// the position after it, from the snapshot it was added with
+	FEN    string
+	Played time.Time
+}
+
+type GameStore interface {
+	// ErrGameID if g.ID is taken
+	CreateGame(g Game) error
+	// ErrNoGame if there's no game with that id
+	Game(id []byte) (Game, error)
+	// the game's moves, in order
+	Moves(id []byte) ([]Move, error)
+	// records m and after, the game's snapshot once it's played; if after
+	// ends the game it's finished at m.Played. ErrPly unless m.Ply is the
+	// number of moves so far, and ErrGameOver if the game's finished
+	AddMove(id []byte, m Move, after chesster.Snapshot) error
+	// records the game's result; ErrGameOver if it already has one
+	FinishGame(id []byte, state chesster.GameState, at time.Time) error
+	// every game still in play, oldest first
+	ActiveGames() ([]Game, error)
+	// the games a user has played in, newest first
+	PlayerGames(user string) ([]Game, error)
+}
+
+type Store interface {
+	auth.UserStore
+	auth.KeyStore
+	auth.SessionStore
+	auth.SecretStore
+	GameStore
+	Close() error
+}
+
+func NewGameID() ([]byte, error) {
+	id := make([]byte, GameIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
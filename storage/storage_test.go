@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	auth "github.com/cactorium/chesster-server/auth"
+	chesster "github.com/cactorium/chesster-server/chesster"
+)
+
+// runs a test against each kind of Store
+func forEachStore(t *testing.T, f func(t *testing.T, s Store)) {
+	t.Run("memory", func(t *testing.T) {
+		f(t, NewMemory())
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		s, err := Open(":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		f(t, s)
+	})
+}
+
+func TestUsers(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		if _, err := s.User("alice"); err != auth.ErrNoUser {
+			t.Errorf("expected %v got %v", auth.ErrNoUser, err)
+		}
+		u := auth.User{Name: "alice", Salt: []byte("salt"), PasswordHash: []byte("hash")}
+		if err := s.Add(u); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Add(u); err != auth.ErrUserExists {
+			t.Errorf("expected %v got %v", auth.ErrUserExists, err)
+		}
+		got, err := s.User("alice")
+		if err != nil || got.Name != "alice" || string(got.Salt) != "salt" || string(got.PasswordHash) != "hash" {
+			t.Errorf("expected %+v got %+v, %v", u, got, err)
+		}
+	})
+}
+
+func TestDeviceKeys(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		for _, name := range []string{"alice", "bob"} {
+			if err := s.Add(auth.User{Name: name, Salt: []byte{}, PasswordHash: []byte{}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, k := range []auth.DeviceKey{
+			{User: "alice", Pub: []byte("k2"), DeviceType: []byte("tablet")},
+			{User: "alice", Pub: []byte("k1"), DeviceType: []byte("phone")},
+			{User: "bob", Pub: []byte("k3")},
+		} {
+			if err := s.AddKey(k); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.AddKey(auth.DeviceKey{User: "bob", Pub: []byte("k1")}); err != auth.ErrKeyExists {
+			t.Errorf("expected %v got %v", auth.ErrKeyExists, err)
+		}
+		if k, err := s.Key("alice", []byte("k1")); err != nil || string(k.DeviceType) != "phone" {
+			t.Errorf("expected alice's phone got %+v, %v", k, err)
+		}
+		if _, err := s.Key("bob", []byte("k1")); err != auth.ErrNoKey {
+			t.Errorf("someone else's key: expected %v got %v", auth.ErrNoKey, err)
+		}
+		ks, err := s.Keys("alice")
+		if err != nil || len(ks) != 2 || string(ks[0].Pub) != "k1" || string(ks[1].Pub) != "k2" {
+			t.Errorf("expected k1, k2 got %+v, %v", ks, err)
+		}
+
+		if err := s.RevokeKey("bob", []byte("k1")); err != auth.ErrNoKey {
+			t.Errorf("revoking someone else's key: expected %v got %v", auth.ErrNoKey, err)
+		}
+		if err := s.RevokeKey("alice", []byte("k1")); err != nil {
+			t.Fatal(err)
+		}
+		if ks, _ := s.Keys("alice"); len(ks) != 1 {
+			t.Errorf("expected one key left got %+v", ks)
+		}
+		if err := s.RevokeKey("alice", []byte("k1")); err != auth.ErrNoKey {
+			t.Errorf("revoking twice: expected %v got %v", auth.ErrNoKey, err)
+		}
+	})
+}
+
+func TestSessions(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		expiry := time.Unix(1500000000, 0)
+		a := auth.SavedSession{Token: []byte("a"), User: "alice", Expiry: expiry, State: []byte("1")}
+		b := auth.SavedSession{Token: []byte("b"), User: "bob", Expiry: expiry, State: []byte("2")}
+		for _, ss := range []auth.SavedSession{a, b} {
+			if err := s.SaveSession(ss); err != nil {
+				t.Fatal(err)
+			}
+		}
+		a.State = []byte("3")
+		if err := s.SaveSession(a); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.DeleteSession(b.Token); err != nil {
+			t.Fatal(err)
+		}
+		ss, err := s.LoadSessions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ss) != 1 || string(ss[0].Token) != "a" || ss[0].User != "alice" || !ss[0].Expiry.Equal(expiry) || string(ss[0].State) != "3" {
+			t.Errorf("expected %+v got %+v", a, ss)
+		}
+	})
+}
+
+func TestSecrets(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		if k, err := s.Secret("server", []byte("first")); err != nil || string(k) != "first" {
+			t.Errorf("expected first got %q, %v", k, err)
+		}
+		if k, err := s.Secret("server", []byte("second")); err != nil || string(k) != "first" {
+			t.Errorf("expected the secret kept got %q, %v", k, err)
+		}
+		if k, err := s.Secret("other", []byte("third")); err != nil || string(k) != "third" {
+			t.Errorf("expected third got %q, %v", k, err)
+		}
+	})
+}
+
+func TestGames(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s Store) {
+		start := time.Unix(1500000000, 0)
+		games := []Game{
+			{ID: []byte("g1"), White: "alice", Black: "bob", Started: start},
+			{ID: []byte("g2"), White: "bob", Black: "carol", Started: start.Add(time.Minute)},
+			{ID: []byte("g3"), White: "carol", Black: "alice", StartFEN: "8/8/8/8/8/8/8/K6k w - - 0 1", Started: start.Add(2 * time.Minute)},
+		}
+		for _, g := range games {
+			if err := s.CreateGame(g); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.CreateGame(games[0]); err != ErrGameID {
+			t.Errorf("expected %v got %v", ErrGameID, err)
+		}
+		if g, err := s.Game([]byte("g3")); err != nil || g.StartFEN != games[2].StartFEN || !g.Started.Equal(games[2].Started) || !g.Finished.IsZero() {
+			t.Errorf("expected %+v got %+v, %v", games[2], g, err)
+		}
+		if _, err := s.Game([]byte("nope")); err != ErrNoGame {
+			t.Errorf("expected %v got %v", ErrNoGame, err)
+		}
+
+		for i, uci := range []string{"e2e4", "e7e5"} {
+			after := chesster.Snapshot{FEN: "after " + uci, MovesSinceCapture: i + 1}
+			if err := s.AddMove([]byte("g1"), Move{Ply: i, UCI: uci, Played: start.Add(time.Duration(i) * time.Second)}, after); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.AddMove([]byte("g1"), Move{Ply: 1, UCI: "d2d4"}, chesster.Snapshot{}); err != ErrPly {
+			t.Errorf("repeated ply: expected %v got %v", ErrPly, err)
+		}
+		if err := s.AddMove([]byte("g1"), Move{Ply: 3, UCI: "d2d4"}, chesster.Snapshot{}); err != ErrPly {
+			t.Errorf("skipped ply: expected %v got %v", ErrPly, err)
+		}
+		if err := s.AddMove([]byte("nope"), Move{UCI: "e2e4"}, chesster.Snapshot{}); err != ErrNoGame {
+			t.Errorf("expected %v got %v", ErrNoGame, err)
+		}
+		ms, err := s.Moves([]byte("g1"))
+		if err != nil || len(ms) != 2 || ms[0].UCI != "e2e4" || ms[1].UCI != "e7e5" || ms[1].FEN != "after e7e5" || !ms[1].Played.Equal(start.Add(time.Second)) {
+			t.Errorf("expected e2e4 e7e5 got %+v, %v", ms, err)
+		}
+		if g, _ := s.Game([]byte("g1")); g.FEN != "after e7e5" || g.MovesSinceCapture != 2 || g.State != chesster.InPlay || !g.Finished.IsZero() {
+			t.Errorf("expected the snapshot after e7e5 got %+v", g)
+		}
+
+		end := start.Add(time.Hour)
+		if err := s.FinishGame([]byte("g1"), chesster.WhiteResigned, end); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.FinishGame([]byte("g1"), chesster.BlackResigned, end); err != ErrGameOver {
+			t.Errorf("finishing twice: expected %v got %v", ErrGameOver, err)
+		}
+		if err := s.AddMove([]byte("g1"), Move{Ply: 2, UCI: "g1f3"}, chesster.Snapshot{}); err != ErrGameOver {
+			t.Errorf("move after the end: expected %v got %v", ErrGameOver, err)
+		}
+		if g, _ := s.Game([]byte("g1")); g.State != chesster.WhiteResigned || !g.Finished.Equal(end) {
+			t.Errorf("expected white resigned at %v got %+v", end, g)
+		}
+
+		// a move that ends the game finishes it
+		mate := chesster.Snapshot{FEN: "mate", State: chesster.BlackCheckmate, WhiteCheck: true}
+		if err := s.AddMove([]byte("g2"), Move{UCI: "d8h4", Played: end}, mate); err != nil {
+			t.Fatal(err)
+		}
+		if g, _ := s.Game([]byte("g2")); g.Snapshot != mate || !g.Finished.Equal(end) {
+			t.Errorf("expected checkmate at %v got %+v", end, g)
+		}
+
+		ids := func(gs []Game, err error) string {
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := ""
+			for _, g := range gs {
+				r += string(g.ID) + " "
+			}
+			return r
+		}
+		if got := ids(s.ActiveGames()); got != "g3 " {
+			t.Errorf("active games: expected g3 got %s", got)
+		}
+		if got := ids(s.PlayerGames("alice")); got != "g3 g1 " {
+			t.Errorf("alice's games: expected g3 g1 got %s", got)
+		}
+	})
+}
+
+func TestReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chesster.db")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(auth.User{Name: "alice", Salt: []byte("salt"), PasswordHash: []byte("hash")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Secret("server", []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateGame(Game{ID: []byte("g1"), White: "alice", Black: "bob", Started: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddMove([]byte("g1"), Move{UCI: "e2e4", Played: time.Now()}, chesster.Snapshot{}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if v, err := (&Migrator{db: s.db}).Version(); err != nil || v != len(migrations) {
+		t.Errorf("expected version %d got %d, %v", len(migrations), v, err)
+	}
+	if _, err := s.User("alice"); err != nil {
+		t.Errorf("user lost: %v", err)
+	}
+	if k, err := s.Secret("server", []byte("new")); err != nil || string(k) != "secret" {
+		t.Errorf("secret lost: %q, %v", k, err)
+	}
+	if gs, err := s.ActiveGames(); err != nil || len(gs) != 1 {
+		t.Errorf("game lost: %+v, %v", gs, err)
+	}
+	if ms, err := s.Moves([]byte("g1")); err != nil || len(ms) != 1 {
+		t.Errorf("move lost: %+v, %v", ms, err)
+	}
+}